@@ -16,24 +16,54 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "lyrics-library/docs"
+	"lyrics-library/internal/client/chain"
 	authGRPC "lyrics-library/internal/client/grpc/auth"
-	"lyrics-library/internal/client/http/track/lyricsovh"
-	"lyrics-library/internal/client/http/track/yandex"
+	"lyrics-library/internal/client/translator"
 	"lyrics-library/internal/config"
+	"lyrics-library/internal/domain/model"
 	"lyrics-library/internal/lib/logger/sl"
 	"lyrics-library/internal/lib/logger/slogpretty"
+	"lyrics-library/internal/service/apikey"
 	authService "lyrics-library/internal/service/auth"
+	"lyrics-library/internal/service/batch"
+	"lyrics-library/internal/service/collection"
+	"lyrics-library/internal/service/job"
+	"lyrics-library/internal/service/role"
+	"lyrics-library/internal/service/token"
 	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/storage/memory"
 	"lyrics-library/internal/storage/postgres"
 	"lyrics-library/internal/storage/redis"
+	apiKeyCreate "lyrics-library/internal/transport/handler/apikey/create"
+	apiKeyList "lyrics-library/internal/transport/handler/apikey/list"
+	apiKeyRevoke "lyrics-library/internal/transport/handler/apikey/revoke"
 	"lyrics-library/internal/transport/handler/auth/login"
 	"lyrics-library/internal/transport/handler/auth/register"
+	collectionAdd "lyrics-library/internal/transport/handler/collection/add"
+	collectionCreate "lyrics-library/internal/transport/handler/collection/create"
+	collectionDel "lyrics-library/internal/transport/handler/collection/delete"
+	collectionGet "lyrics-library/internal/transport/handler/collection/get"
+	collectionList "lyrics-library/internal/transport/handler/collection/list"
+	collectionRemove "lyrics-library/internal/transport/handler/collection/remove"
+	collectionReorder "lyrics-library/internal/transport/handler/collection/reorder"
+	collectionShare "lyrics-library/internal/transport/handler/collection/share"
+	collectionShared "lyrics-library/internal/transport/handler/collection/shared"
+	collectionUnshare "lyrics-library/internal/transport/handler/collection/unshare"
+	jobGet "lyrics-library/internal/transport/handler/job/get"
+	trackBatch "lyrics-library/internal/transport/handler/track/batch"
 	"lyrics-library/internal/transport/handler/track/create"
 	del "lyrics-library/internal/transport/handler/track/delete"
+	trackExport "lyrics-library/internal/transport/handler/track/export"
+	"lyrics-library/internal/transport/handler/track/get"
+	"lyrics-library/internal/transport/handler/track/line"
+	"lyrics-library/internal/transport/handler/track/mine"
 	"lyrics-library/internal/transport/handler/track/read"
+	"lyrics-library/internal/transport/handler/track/search"
+	"lyrics-library/internal/transport/handler/track/update"
 	mwAuth "lyrics-library/internal/transport/middleware/auth"
 	healthChecker "lyrics-library/internal/transport/middleware/health-checker"
 	mwLogger "lyrics-library/internal/transport/middleware/logger"
+	"lyrics-library/internal/transport/middleware/policy"
 )
 
 const (
@@ -75,51 +105,125 @@ func main() {
 
 	log.Debug("connecting to redis", slog.String("host", redisHost))
 
-	cache, err := redis.New(redisHost, cfg.Redis.Password)
+	cache, err := redis.New(redisHost, cfg.Redis)
+	if err != nil {
+		panic(err)
+	}
+
+	lyricsClient, err := chain.FromConfig(log, cfg.LyricsAPI)
+	if err != nil {
+		panic(err)
+	}
+
+	translateClient, err := translator.New(log, cfg.TranslatorAPI)
 	if err != nil {
 		panic(err)
 	}
 
-	lyricsClient := lyricsovh.New(log, cfg.LyricsAPI.URL)
-	translateClient := yandex.New(log,
-		cfg.TranslatorAPI.Key,
-		cfg.TranslatorAPI.URL,
-		cfg.TranslatorAPI.TargetLang,
-	)
 	authClient, err := authGRPC.New(log, cfg)
 	if err != nil {
 		panic(err)
 	}
 
+	var locker track.Locker
+	if cfg.Redis.Lock.Enabled {
+		locker = cache
+	}
+
 	trackService := track.New(
 		log,
 		lyricsClient,
 		translateClient,
 		storage,
 		cache,
+		locker,
+		cfg.TranslatorAPI.TargetLang,
 	)
+	jobService := job.New(log, storage, trackService, cfg.Jobs)
+	batchService := batch.New(log, trackService, jobService, cfg.Batch.Concurrency)
+	collectionService := collection.New(log, storage)
 	auth := authService.New(log, authClient)
+	apiKeyService := apikey.New(log, storage)
+
+	roleService, err := role.New(log, cfg.Auth.RolesSource, storage)
+	if err != nil {
+		panic(err)
+	}
+
+	tokenCache, err := newTokenCache(cfg.Auth.TokenCache, cache)
+	if err != nil {
+		panic(err)
+	}
+
+	tokenParser, err := token.NewParser(ctx, log, cfg.Auth.Verifier, authClient)
+	if err != nil {
+		panic(err)
+	}
+
+	tokenService := token.New(log, tokenParser, tokenCache, cfg.Auth.TokenCache)
+
+	jobsDone := make(chan struct{})
+	go func() {
+		defer close(jobsDone)
+		jobService.Run(ctx)
+	}()
 
 	g := gin.New()
 
 	g.Use(gin.Recovery())
 	g.Use(healthChecker.New(log, storage))
 	g.Use(mwLogger.New(log))
-	g.Use(mwAuth.New(log, authClient))
+	g.Use(mwAuth.New(log, tokenService, apiKeyService, roleService))
 
 	g.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	authGroup := g.Group("/auth")
 	{
 		authGroup.POST("/register", register.New(ctx, log, auth))
-		authGroup.POST("/login", login.New(ctx, log, auth))
+		authGroup.POST("/login", login.New(ctx, log, auth, cfg.Cookie))
+	}
+
+	apiKeysGroup := g.Group("/auth/api-keys", policy.Authenticated(log))
+	{
+		apiKeysGroup.POST("", apiKeyCreate.New(ctx, log, apiKeyService))
+		apiKeysGroup.GET("", apiKeyList.New(ctx, log, apiKeyService))
+		apiKeysGroup.DELETE("/:id", apiKeyRevoke.New(ctx, log, apiKeyService))
 	}
 
 	lyricsGroup := g.Group("/lyrics")
 	{
-		lyricsGroup.POST("/", create.New(ctx, log, trackService))
+		lyricsGroup.POST("/", policy.Authenticated(log), create.New(ctx, log, trackService, jobService))
+		lyricsGroup.POST("/batch", policy.Role(log, model.RoleAdmin),
+			trackBatch.New(ctx, log, batchService, cfg.Batch.MaxItems, cfg.Batch.SyncMaxItems))
 		lyricsGroup.GET("/", read.New(ctx, log, trackService, trackService))
-		lyricsGroup.DELETE("/:uuid", del.New(ctx, log, trackService))
+		lyricsGroup.GET("/mine", policy.Authenticated(log), mine.New(ctx, log, trackService))
+		lyricsGroup.GET("/search", search.New(ctx, log, trackService))
+		lyricsGroup.GET("/export", policy.Authenticated(log), trackExport.New(ctx, log, trackService, cfg.TranslatorAPI.TargetLang))
+		lyricsGroup.GET("/:uuid", get.New(ctx, log, trackService))
+		lyricsGroup.GET("/:uuid/line", line.New(ctx, log, trackService))
+		lyricsGroup.PUT("/:uuid", policy.Authenticated(log), update.New(ctx, log, trackService))
+		lyricsGroup.DELETE("/:uuid", policy.Authenticated(log), del.New(ctx, log, trackService))
+	}
+
+	// shared collections are readable by anyone with the slug
+	g.GET("/collections/shared/:slug", collectionShared.New(ctx, log, collectionService))
+
+	collectionsGroup := g.Group("/collections", policy.Authenticated(log))
+	{
+		collectionsGroup.POST("", collectionCreate.New(ctx, log, collectionService))
+		collectionsGroup.GET("", collectionList.New(ctx, log, collectionService))
+		collectionsGroup.GET("/:id", collectionGet.New(ctx, log, collectionService))
+		collectionsGroup.DELETE("/:id", collectionDel.New(ctx, log, collectionService))
+		collectionsGroup.POST("/:id/tracks", collectionAdd.New(ctx, log, collectionService))
+		collectionsGroup.PUT("/:id/tracks", collectionReorder.New(ctx, log, collectionService))
+		collectionsGroup.DELETE("/:id/tracks/:uuid", collectionRemove.New(ctx, log, collectionService))
+		collectionsGroup.POST("/:id/share", collectionShare.New(ctx, log, collectionService))
+		collectionsGroup.DELETE("/:id/share", collectionUnshare.New(ctx, log, collectionService))
+	}
+
+	jobsGroup := g.Group("/jobs")
+	{
+		jobsGroup.GET("/:id", jobGet.New(ctx, log, jobService))
 	}
 
 	srv := &http.Server{
@@ -154,6 +258,12 @@ func main() {
 		log.Error("failed to shutdown server", sl.Err(err))
 	}
 
+	select {
+	case <-jobsDone:
+	case <-shutdownCtx.Done():
+		log.Error("job workers didn't stop in time, unfinished jobs are run again later")
+	}
+
 	if err := storage.Close(shutdownCtx); err != nil {
 		log.Error("failed to close storage", sl.Err(err))
 	}
@@ -201,6 +311,20 @@ func connURL(cfg *config.Config) string {
 		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.DockerPort, cfg.DB.Name)
 }
 
+// newTokenCache returns the cache of validated tokens, nil if caching is disabled
+func newTokenCache(cfg config.AuthTokenCacheConfig, cache *redis.Storage) (token.Cache, error) {
+	switch cfg.Backend {
+	case token.BackendMemory:
+		return memory.New(cfg.Size), nil
+	case token.BackendRedis:
+		return cache, nil
+	case token.BackendNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown token cache backend %q", cfg.Backend)
+	}
+}
+
 func redisHost(cfg *config.Config) string {
 	return fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.DockerPort)
 }
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"lyrics-library/internal/client/chain"
+	"lyrics-library/internal/client/translator"
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/playlist"
+	"lyrics-library/internal/service/batch"
+	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/storage/postgres"
+	"lyrics-library/internal/storage/redis"
+)
+
+const closeTimeout = 5 * time.Second
+
+// Import saves every track of a JSON or CSV playlist the same way POST /lyrics/batch does:
+//
+//	go run ./cmd/import -config=./.env -file=playlist.csv [-lang=uk] [-user=1] [-concurrency=4]
+//
+// The file format is taken from the extension unless -format is set, "-" reads stdin
+func main() {
+	var (
+		file        string
+		format      string
+		lang        string
+		userID      int64
+		concurrency int
+	)
+
+	flag.StringVar(&file, "file", "", "path to the JSON or CSV playlist, - for stdin")
+	flag.StringVar(&format, "format", "", "playlist format, json or csv (default from the file extension)")
+	flag.StringVar(&lang, "lang", "", "translation language of tracks without their own lang")
+	flag.Int64Var(&userID, "user", 0, "id of the user owning the imported tracks, 0 for none: such tracks can only be deleted by an admin")
+	flag.IntVar(&concurrency, "concurrency", 0, "tracks imported at once (default BATCH_CONCURRENCY)")
+
+	cfg := config.MustLoad()
+
+	if file == "" {
+		fmt.Fprintln(os.Stderr, "-file is required")
+		os.Exit(2)
+	}
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	items, err := readPlaylist(file, format)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for i := range items {
+		if items[i].Lang == "" {
+			items[i].Lang = lang
+		}
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	storage, err := postgres.New(connURL(cfg))
+	if err != nil {
+		panic(err)
+	}
+
+	cache, err := redis.New(redisHost(cfg), cfg.Redis)
+	if err != nil {
+		panic(err)
+	}
+
+	lyricsClient, err := chain.FromConfig(log, cfg.LyricsAPI)
+	if err != nil {
+		panic(err)
+	}
+
+	translateClient, err := translator.New(log, cfg.TranslatorAPI)
+	if err != nil {
+		panic(err)
+	}
+
+	var locker track.Locker
+	if cfg.Redis.Lock.Enabled {
+		locker = cache
+	}
+
+	trackService := track.New(
+		log,
+		lyricsClient,
+		translateClient,
+		storage,
+		cache,
+		locker,
+		cfg.TranslatorAPI.TargetLang,
+	)
+
+	if concurrency <= 0 {
+		concurrency = cfg.Batch.Concurrency
+	}
+
+	results := batch.New(log, trackService, nil, concurrency).Import(ctx, items, userID)
+
+	counts := make(map[string]int)
+	for _, result := range results {
+		counts[result.Status]++
+
+		fmt.Printf("%s\t%s - %s\t%s\n", result.Status, result.Item.Artist, result.Item.Title, result.TrackUUID)
+	}
+
+	fmt.Printf("%d tracks: %d saved, %d existed, %d not found, %d translation failed, %d failed\n",
+		len(results),
+		counts[model.BatchSaved],
+		counts[model.BatchExists],
+		counts[model.BatchNotFound],
+		counts[model.BatchTranslationFailed],
+		counts[model.BatchFailed],
+	)
+
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer closeCancel()
+
+	if err := storage.Close(closeCtx); err != nil {
+		log.Error("failed to close storage", sl.Err(err))
+	}
+
+	if err := cache.Close(closeCtx); err != nil {
+		log.Error("failed to close redis", sl.Err(err))
+	}
+}
+
+func readPlaylist(file, format string) ([]model.BatchItem, error) {
+	var r io.Reader = os.Stdin
+
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		r = f
+	}
+
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(file)), ".")
+	}
+
+	switch format {
+	case "csv":
+		return playlist.ParseCSV(r)
+	case "json", "":
+		return playlist.ParseJSON(r)
+	default:
+		return nil, fmt.Errorf("unknown playlist format: %s", format)
+	}
+}
+
+func connURL(cfg *config.Config) string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.DockerPort, cfg.DB.Name)
+}
+
+func redisHost(cfg *config.Config) string {
+	return fmt.Sprintf("%s:%s", cfg.Redis.Host, cfg.Redis.DockerPort)
+}
@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"lyrics-library/internal/client/http/track"
+	"lyrics-library/internal/lib/logger/sl"
+)
+
+// Source is a single lyrics backend
+type Source interface {
+	Lyrics(ctx context.Context, artist, title string) ([]string, error)
+}
+
+// Provider is a named source with its own request timeout,
+// zero Timeout leaves the caller's deadline as is
+type Provider struct {
+	Name    string
+	Source  Source
+	Timeout time.Duration
+}
+
+// Chain fetches lyrics from the first provider that has them
+type Chain struct {
+	log       *slog.Logger
+	providers []Provider
+	parallel  bool
+}
+
+// New returns a chain trying providers in the given order,
+// in parallel mode all of them are queried at once and the first found lyrics win
+func New(log *slog.Logger, parallel bool, providers ...Provider) *Chain {
+	return &Chain{
+		log:       log,
+		providers: providers,
+		parallel:  parallel,
+	}
+}
+
+type result struct {
+	provider string
+	lyrics   []string
+	err      error
+}
+
+// Lyrics returns the lyrics with the name of the provider that served them.
+// track.ErrLyricsNotFound is returned only if every provider reported the track missing
+func (c *Chain) Lyrics(ctx context.Context, artist, title string) ([]string, string, error) {
+	const op = "client.chain.Lyrics"
+
+	log := c.log.With(slog.String("op", op),
+		slog.String("artist", artist),
+		slog.String("title", title),
+	)
+
+	if c.parallel {
+		return c.parallelLyrics(ctx, log, artist, title)
+	}
+
+	var errs []error
+
+	for _, p := range c.providers {
+		res := c.fetch(ctx, p, artist, title)
+		if res.err == nil {
+			log.Info("lyrics found", slog.String("provider", p.Name))
+
+			return res.lyrics, p.Name, nil
+		}
+
+		errs = c.collect(log, errs, res)
+	}
+
+	return nil, "", c.notFound(op, errs)
+}
+
+func (c *Chain) parallelLyrics(
+	ctx context.Context,
+	log *slog.Logger,
+	artist, title string,
+) ([]string, string, error) {
+	const op = "client.chain.Lyrics"
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan result, len(c.providers))
+
+	for _, p := range c.providers {
+		go func() {
+			results <- c.fetch(ctx, p, artist, title)
+		}()
+	}
+
+	var errs []error
+
+	for range c.providers {
+		res := <-results
+		if res.err == nil {
+			log.Info("lyrics found", slog.String("provider", res.provider))
+
+			return res.lyrics, res.provider, nil
+		}
+
+		errs = c.collect(log, errs, res)
+	}
+
+	return nil, "", c.notFound(op, errs)
+}
+
+func (c *Chain) fetch(ctx context.Context, p Provider, artist, title string) result {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	lyrics, err := p.Source.Lyrics(ctx, artist, title)
+	if err == nil && len(lyrics) == 0 {
+		err = track.ErrLyricsNotFound
+	}
+
+	return result{provider: p.Name, lyrics: lyrics, err: err}
+}
+
+// collect keeps provider failures, tracks missing from a provider aren't failures
+func (c *Chain) collect(log *slog.Logger, errs []error, res result) []error {
+	if errors.Is(res.err, track.ErrLyricsNotFound) {
+		log.Debug("lyrics not found", slog.String("provider", res.provider))
+
+		return errs
+	}
+
+	log.Warn("provider failed", slog.String("provider", res.provider), sl.Err(res.err))
+
+	return append(errs, fmt.Errorf("%s: %w", res.provider, res.err))
+}
+
+func (c *Chain) notFound(op string, errs []error) error {
+	if len(errs) == 0 {
+		return fmt.Errorf("%s: %w", op, track.ErrLyricsNotFound)
+	}
+
+	return fmt.Errorf("%s: %w", op, errors.Join(errs...))
+}
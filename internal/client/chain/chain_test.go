@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/client/http/track"
+	"lyrics-library/internal/client/http/track/jsonapi"
+	"lyrics-library/internal/config"
+)
+
+func newServer(t *testing.T, status int, body string, delay time.Duration) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func provider(log *slog.Logger, name string, srv *httptest.Server, timeout time.Duration) Provider {
+	return Provider{
+		Name:    name,
+		Source:  jsonapi.New(log, srv.URL+"/{artist}/{title}", "lyrics", "", ""),
+		Timeout: timeout,
+	}
+}
+
+func TestChain_Lyrics(t *testing.T) {
+	t.Parallel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	missing := newServer(t, http.StatusNotFound, `{}`, 0)
+	broken := newServer(t, http.StatusInternalServerError, `{}`, 0)
+	slow := newServer(t, http.StatusOK, `{"lyrics":"slow line"}`, time.Second)
+	found := newServer(t, http.StatusOK, `{"lyrics":"first line\nsecond line"}`, 0)
+	other := newServer(t, http.StatusOK, `{"lyrics":"other line"}`, 0)
+
+	tests := []struct {
+		name             string
+		parallel         bool
+		providers        []Provider
+		expectedLyrics   []string
+		expectedProvider string
+		expectedNotFound bool
+		expectedError    bool
+	}{
+		{
+			name: "falls back to next provider",
+			providers: []Provider{
+				provider(log, "missing", missing, 0),
+				provider(log, "broken", broken, 0),
+				provider(log, "found", found, 0),
+				provider(log, "other", other, 0),
+			},
+			expectedLyrics:   []string{"first line", "second line"},
+			expectedProvider: "found",
+		},
+		{
+			name: "provider timeout",
+			providers: []Provider{
+				provider(log, "slow", slow, 50*time.Millisecond),
+				provider(log, "other", other, 0),
+			},
+			expectedLyrics:   []string{"other line"},
+			expectedProvider: "other",
+		},
+		{
+			name:     "parallel takes first found",
+			parallel: true,
+			providers: []Provider{
+				provider(log, "slow", slow, 0),
+				provider(log, "missing", missing, 0),
+				provider(log, "found", found, 0),
+			},
+			expectedLyrics:   []string{"first line", "second line"},
+			expectedProvider: "found",
+		},
+		{
+			name: "not found anywhere",
+			providers: []Provider{
+				provider(log, "missing", missing, 0),
+				provider(log, "missing again", missing, 0),
+			},
+			expectedNotFound: true,
+		},
+		{
+			name:     "provider failed",
+			parallel: true,
+			providers: []Provider{
+				provider(log, "missing", missing, 0),
+				provider(log, "broken", broken, 0),
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := New(log, tt.parallel, tt.providers...)
+
+			lyrics, name, err := c.Lyrics(context.Background(), "Artist", "Song")
+
+			switch {
+			case tt.expectedNotFound:
+				assert.ErrorIs(t, err, track.ErrLyricsNotFound)
+			case tt.expectedError:
+				assert.Error(t, err)
+				assert.NotErrorIs(t, err, track.ErrLyricsNotFound)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedLyrics, lyrics)
+				assert.Equal(t, tt.expectedProvider, name)
+			}
+		})
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	c, err := FromConfig(log, config.LyricsAPIConfig{
+		Providers: []string{"lyricsovh", " local"},
+		Timeout:   time.Second,
+		Local:     config.LyricsLocalConfig{Timeout: 2 * time.Second},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ProviderLyricsOVH, ProviderLocal}, []string{c.providers[0].Name, c.providers[1].Name})
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, []time.Duration{c.providers[0].Timeout, c.providers[1].Timeout})
+
+	c, err = FromConfig(log, config.LyricsAPIConfig{Providers: []string{"genius"}})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+	assert.Nil(t, c)
+}
@@ -0,0 +1,60 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"lyrics-library/internal/client/fs/track/localdir"
+	"lyrics-library/internal/client/http/track/jsonapi"
+	"lyrics-library/internal/client/http/track/lyricsovh"
+	"lyrics-library/internal/config"
+)
+
+const (
+	ProviderLyricsOVH = "lyricsovh"
+	ProviderHTTP      = "http"
+	ProviderLocal     = "local"
+)
+
+var ErrUnknownProvider = errors.New("unknown lyrics provider")
+
+// FromConfig chains lyrics sources in the order of the LYRICS_API_ config
+func FromConfig(log *slog.Logger, cfg config.LyricsAPIConfig) (*Chain, error) {
+	providers := make([]Provider, 0, len(cfg.Providers))
+
+	for _, name := range cfg.Providers {
+		var p Provider
+
+		switch name = strings.TrimSpace(name); name {
+		case ProviderLyricsOVH:
+			p = Provider{
+				Source:  lyricsovh.New(log, cfg.URL),
+				Timeout: cfg.Timeout,
+			}
+		case ProviderHTTP:
+			p = Provider{
+				Source: jsonapi.New(log,
+					cfg.HTTP.URL,
+					cfg.HTTP.LyricsField,
+					cfg.HTTP.AuthHeader,
+					cfg.HTTP.AuthToken,
+				),
+				Timeout: cfg.HTTP.Timeout,
+			}
+		case ProviderLocal:
+			p = Provider{
+				Source:  localdir.New(log, cfg.Local.Dir),
+				Timeout: cfg.Local.Timeout,
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownProvider, name)
+		}
+
+		p.Name = name
+		providers = append(providers, p)
+	}
+
+	return New(log, cfg.Parallel, providers...), nil
+}
@@ -0,0 +1,144 @@
+package localdir
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"lyrics-library/internal/client/http/track"
+)
+
+const (
+	extTXT = ".txt"
+	extLRC = ".lrc"
+)
+
+// lrcTags matches timestamps and metadata tags like [01:02.30] or [ar:Artist] at line start
+var lrcTags = regexp.MustCompile(`(?m)^\s*(\[[^\]]*\]\s*)+`)
+
+var errFound = errors.New("found")
+
+// Client reads lyrics from .txt and .lrc files stored either as
+// "<dir>/<artist>/<title>.txt" or "<dir>/<artist> - <title>.txt",
+// artist and title are matched case insensitively
+type Client struct {
+	log *slog.Logger
+	dir string
+}
+
+func New(log *slog.Logger, dir string) *Client {
+	return &Client{
+		log: log,
+		dir: dir,
+	}
+}
+
+func (c *Client) Lyrics(ctx context.Context, artist, title string) ([]string, error) {
+	const op = "client.fs.track.localdir.Lyrics"
+
+	log := c.log.With(slog.String("op", op),
+		slog.String("artist", artist),
+		slog.String("title", title),
+	)
+
+	log.Info("Fetching track")
+
+	path, err := c.find(ctx, artist, title)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Debug("lyrics file", slog.String("path", path))
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	lyrics := string(data)
+
+	// lrc lines keep their timestamps so the lyrics are stored time-synced,
+	// a file of tags only has no lyrics
+	isLRC := strings.EqualFold(filepath.Ext(path), extLRC)
+	if isLRC && strings.TrimSpace(lrcTags.ReplaceAllString(lyrics, "")) == "" {
+		lyrics = ""
+	}
+
+	formatted := track.FormatLyrics(lyrics)
+	if len(formatted) == 0 {
+		return nil, fmt.Errorf("%s: %w", op, track.ErrLyricsNotFound)
+	}
+
+	log.Info("track fetched successfully")
+
+	return formatted, nil
+}
+
+// find walks the directory instead of joining artist and title into a path,
+// so request values can't point outside of it
+func (c *Client) find(ctx context.Context, artist, title string) (string, error) {
+	artist, title = normalize(artist), normalize(title)
+
+	var found string
+
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		ext := filepath.Ext(path)
+		if !strings.EqualFold(ext, extTXT) && !strings.EqualFold(ext, extLRC) {
+			return nil
+		}
+
+		if fileArtist, fileTitle := trackOf(c.dir, path); fileArtist == artist && fileTitle == title {
+			found = path
+
+			return errFound
+		}
+
+		return nil
+	})
+	if errors.Is(err, errFound) {
+		return found, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return "", track.ErrLyricsNotFound
+}
+
+// trackOf returns the normalized artist and title the file is named after
+func trackOf(dir, path string) (string, string) {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+
+	if parent := filepath.Dir(path); parent != filepath.Clean(dir) {
+		return normalize(filepath.Base(parent)), normalize(name)
+	}
+
+	artist, title, ok := strings.Cut(name, " - ")
+	if !ok {
+		return "", ""
+	}
+
+	return normalize(artist), normalize(title)
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
@@ -0,0 +1,93 @@
+package localdir
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lyrics-library/internal/client/http/track"
+)
+
+func TestClient_Lyrics(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "Juice WRLD"), 0o755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "Juice WRLD", "Lucid Dreams.lrc"),
+		[]byte("[ar:Juice WRLD]\n[00:01.50]I still see your shadows\n[00:04.10][00:30.00]In my room\n"),
+		0o644,
+	))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "Nirvana - Lithium.txt"),
+		[]byte("I'm so happy\r\n\r\nCause today I found my friends\r\n"),
+		0o644,
+	))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Nirvana - Polly.md"), []byte("Polly"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Nirvana - Lounge Act.lrc"), []byte("[ar:Nirvana]\n[ti:Lounge Act]\n"), 0o644))
+
+	tests := []struct {
+		name           string
+		artist         string
+		title          string
+		expectedLyrics []string
+		expectedError  error
+	}{
+		{
+			name:           "lrc in artist directory",
+			artist:         "juice wrld",
+			title:          "LUCID DREAMS",
+			expectedLyrics: []string{"[ar:Juice WRLD]", "[00:01.50]I still see your shadows", "[00:04.10][00:30.00]In my room"},
+		},
+		{
+			name:           "txt named after artist and title",
+			artist:         "Nirvana",
+			title:          "Lithium",
+			expectedLyrics: []string{"I'm so happy", "", "Cause today I found my friends"},
+		},
+		{
+			name:          "unsupported extension",
+			artist:        "Nirvana",
+			title:         "Polly",
+			expectedError: track.ErrLyricsNotFound,
+		},
+		{
+			name:          "lrc with tags only",
+			artist:        "Nirvana",
+			title:         "Lounge Act",
+			expectedError: track.ErrLyricsNotFound,
+		},
+		{
+			name:          "path outside of directory",
+			artist:        "..",
+			title:         "passwd",
+			expectedError: track.ErrLyricsNotFound,
+		},
+	}
+
+	c := New(slog.New(slog.NewTextHandler(io.Discard, nil)), dir)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			lyrics, err := c.Lyrics(context.Background(), tt.artist, tt.title)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, lyrics)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLyrics, lyrics)
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 
 	"lyrics-library/internal/client"
 	"lyrics-library/internal/config"
+	"lyrics-library/internal/lib/breaker"
 )
 
 var (
@@ -29,9 +30,13 @@ type Client struct {
 	log *slog.Logger
 }
 
+// New connects to the auth service, opts are appended to the dial options.
+// Calls are retried up to cfg.Auth.Retries times, a call whose retries all failed
+// counts once towards opening the circuit breaker
 func New(
 	log *slog.Logger,
 	cfg *config.Config,
+	opts ...grpc.DialOption,
 ) (*Client, error) {
 	const op = "client.grpc.auth.New"
 
@@ -49,19 +54,28 @@ func New(
 
 	log.Debug("Auth service address:", slog.String("address", addr))
 
-	cc, err := grpc.NewClient(addr,
+	interceptors := []grpc.UnaryClientInterceptor{
+		grpclog.UnaryClientInterceptor(InterceptorLogger(*log), logOpts...),
+	}
+	if cfg.Auth.Breaker.Failures > 0 {
+		b := breaker.New(cfg.Auth.Breaker.Failures, cfg.Auth.Breaker.Cooldown)
+		interceptors = append(interceptors, BreakerInterceptor(b))
+	}
+	interceptors = append(interceptors, grpcretry.UnaryClientInterceptor(retryOpts...))
+
+	opts = append([]grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithChainUnaryInterceptor(
-			grpclog.UnaryClientInterceptor(InterceptorLogger(*log), logOpts...),
-			grpcretry.UnaryClientInterceptor(retryOpts...),
-		),
-	)
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}, opts...)
+
+	cc, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return &Client{
 		api: ssov1.NewAuthClient(cc),
+		log: log,
 	}, nil
 }
 
@@ -104,7 +118,7 @@ func (c *Client) Login(ctx context.Context, email, password string) (string, err
 }
 
 func (c *Client) ParseToken(ctx context.Context, token string) (int64, error) {
-	const op = "client.grpc.auth.ValidateToken"
+	const op = "client.grpc.auth.ParseToken"
 
 	resp, err := c.api.ParseToken(ctx, &ssov1.ParseTokenRequest{
 		Token: token,
@@ -114,11 +128,43 @@ func (c *Client) ParseToken(ctx context.Context, token string) (int64, error) {
 		if ok && st.Code() == codes.InvalidArgument {
 			return 0, fmt.Errorf("%s: %w", op, ErrInvalidToken)
 		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return resp.Uid, nil
 }
 
+// BreakerInterceptor fails calls with codes.Unavailable while b is open.
+// Only errors telling the service is down or overloaded count as failures,
+// rejected credentials and tokens don't
+func BreakerInterceptor(b *breaker.Breaker) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if err := b.Allow(); err != nil {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		switch status.Code(err) {
+		case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted,
+			codes.Aborted, codes.Internal, codes.Unknown:
+			b.Failure()
+		default:
+			b.Success()
+		}
+
+		return err
+	}
+}
+
 func InterceptorLogger(l slog.Logger) grpclog.Logger {
 	return grpclog.LoggerFunc(func(ctx context.Context, lvl grpclog.Level, msg string, fields ...any) {
 		l.Log(ctx, slog.Level(lvl), msg, fields...)
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ssov1 "github.com/fvckinginsxne/protos/gen/go/sso"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"lyrics-library/internal/client/grpc/auth/authtest"
+	"lyrics-library/internal/config"
+)
+
+type stubServer struct {
+	ssov1.UnimplementedAuthServer
+
+	calls      atomic.Int64
+	parseToken func(token string) (*ssov1.ParseTokenResponse, error)
+}
+
+func (s *stubServer) ParseToken(_ context.Context, req *ssov1.ParseTokenRequest) (*ssov1.ParseTokenResponse, error) {
+	s.calls.Add(1)
+
+	return s.parseToken(req.Token)
+}
+
+func (s *stubServer) Login(_ context.Context, _ *ssov1.LoginRequest) (*ssov1.LoginResponse, error) {
+	return nil, status.Error(codes.InvalidArgument, "invalid email or password")
+}
+
+func (s *stubServer) Register(_ context.Context, _ *ssov1.RegisterRequest) (*ssov1.RegisterResponse, error) {
+	return nil, status.Error(codes.AlreadyExists, "user already exists")
+}
+
+func newClient(t *testing.T, srv *stubServer, breakerFailures int) *Client {
+	t.Helper()
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{
+			Host:    authtest.Host,
+			Port:    "0",
+			Retries: 1,
+			Breaker: config.AuthBreakerConfig{Failures: breakerFailures, Cooldown: time.Minute},
+		},
+	}
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	c, err := New(log, cfg, authtest.Start(t, srv))
+	require.NoError(t, err)
+
+	return c
+}
+
+func TestClient_ParseToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		parseToken    func(token string) (*ssov1.ParseTokenResponse, error)
+		expectedUID   int64
+		expectedError error
+		expectedCode  codes.Code
+	}{
+		{
+			name: "valid token",
+			parseToken: func(token string) (*ssov1.ParseTokenResponse, error) {
+				return &ssov1.ParseTokenResponse{Uid: 42}, nil
+			},
+			expectedUID: 42,
+		},
+		{
+			name: "invalid token",
+			parseToken: func(token string) (*ssov1.ParseTokenResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "invalid token")
+			},
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name: "service failure",
+			parseToken: func(token string) (*ssov1.ParseTokenResponse, error) {
+				return nil, status.Error(codes.Internal, "database is down")
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, &stubServer{parseToken: tt.parseToken}, 0)
+
+			uid, err := c.ParseToken(context.Background(), "token")
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.expectedCode != codes.OK:
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, ErrInvalidToken)
+				assert.Equal(t, tt.expectedCode, status.Code(err))
+			default:
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedUID, uid)
+		})
+	}
+}
+
+func TestClient_Breaker(t *testing.T) {
+	srv := &stubServer{
+		parseToken: func(token string) (*ssov1.ParseTokenResponse, error) {
+			if token == "rejected" {
+				return nil, status.Error(codes.InvalidArgument, "invalid token")
+			}
+
+			return nil, status.Error(codes.Unavailable, "overloaded")
+		},
+	}
+
+	c := newClient(t, srv, 2)
+
+	for range 3 {
+		_, err := c.ParseToken(context.Background(), "rejected")
+		assert.ErrorIs(t, err, ErrInvalidToken, "rejected tokens don't open the breaker")
+	}
+
+	for range 2 {
+		_, err := c.ParseToken(context.Background(), "token")
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	}
+
+	calls := srv.calls.Load()
+
+	_, err := c.ParseToken(context.Background(), "token")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, calls, srv.calls.Load(), "open breaker doesn't call the service")
+}
+
+func TestClient_LoginAndRegister(t *testing.T) {
+	c := newClient(t, &stubServer{}, 0)
+
+	_, err := c.Login(context.Background(), "test@example.com", "wrongpassword")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	err = c.Register(context.Background(), "test@example.com", "password")
+	assert.ErrorIs(t, err, ErrUserAlreadyExists)
+}
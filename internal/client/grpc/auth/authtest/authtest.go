@@ -0,0 +1,37 @@
+package authtest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	ssov1 "github.com/fvckinginsxne/protos/gen/go/sso"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Host makes the client dial the in-process server, the dialer ignores the address
+const Host = "passthrough:///bufnet"
+
+const bufSize = 1 << 20
+
+// Start serves srv in-process until the test ends and returns
+// the dial option connecting a client to it
+func Start(t *testing.T, srv ssov1.AuthServer) grpc.DialOption {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+
+	s := grpc.NewServer()
+	ssov1.RegisterAuthServer(s, srv)
+
+	go func() {
+		_ = s.Serve(lis)
+	}()
+
+	t.Cleanup(s.Stop)
+
+	return grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	})
+}
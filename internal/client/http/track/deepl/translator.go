@@ -0,0 +1,166 @@
+package deepl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	apiClient "lyrics-library/internal/client"
+	"lyrics-library/internal/client/http/track"
+)
+
+// DeepL accepts up to 50 texts per request
+const (
+	maxLines = 50
+	maxChars = 100000
+)
+
+type Request struct {
+	Text       []string `json:"text"`
+	TargetLang string   `json:"target_lang"`
+}
+
+type Response struct {
+	Translations []struct {
+		Text string `json:"text"`
+	} `json:"translations"`
+	Message string `json:"message"`
+}
+
+// Client translates with a DeepL compatible /v2/translate endpoint
+type Client struct {
+	log    *slog.Logger
+	client *http.Client
+	apiKey string
+	apiURL string
+}
+
+func New(log *slog.Logger,
+	apiKey, apiURL string,
+) *Client {
+	return &Client{
+		log:    log,
+		client: &http.Client{},
+		apiKey: apiKey,
+		apiURL: apiURL,
+	}
+}
+
+func (c *Client) TranslateLyrics(
+	ctx context.Context,
+	lyrics []string,
+	targetLang string,
+) ([]string, error) {
+	const op = "client.http.track.deepl.TranslateLyrics"
+
+	log := c.log.With(slog.String("op", op), slog.String("target_lang", targetLang))
+
+	log.Info("translating track")
+
+	translation, err := track.TranslateLines(ctx, lyrics, maxLines, maxChars,
+		func(ctx context.Context, texts []string) ([]string, error) {
+			return c.translateTexts(ctx, log, texts, targetLang)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("track translated successfully")
+
+	return translation, nil
+}
+
+func (c *Client) translateTexts(
+	ctx context.Context,
+	log *slog.Logger,
+	texts []string,
+	targetLang string,
+) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiClient.RequestTimeout)
+	defer cancel()
+
+	req, err := c.buildAPIRequest(ctx, texts, targetLang)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doAPIRequest(log, req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("deepl response", slog.Any("response", res))
+
+	translations := make([]string, len(res.Translations))
+	for i, t := range res.Translations {
+		translations[i] = t.Text
+	}
+
+	return translations, nil
+}
+
+func (c *Client) buildAPIRequest(
+	ctx context.Context,
+	texts []string,
+	targetLang string,
+) (*http.Request, error) {
+	// DeepL expects upper case language codes
+	reqBody, err := json.Marshal(Request{
+		Text:       texts,
+		TargetLang: strings.ToUpper(targetLang),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.apiURL,
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "DeepL-Auth-Key "+c.apiKey)
+
+	return req, nil
+}
+
+func (c *Client) doAPIRequest(log *slog.Logger, req *http.Request) (*Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	log.Debug("response status", slog.String("status", resp.Status))
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Response
+	if err := json.Unmarshal(body, &res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: %s", track.ErrFailedTranslateLyrics, resp.Status)
+		}
+
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		log.Error("deepl error", slog.String("message", res.Message))
+
+		return nil, fmt.Errorf("%w: %s: %s", track.ErrFailedTranslateLyrics, resp.Status, res.Message)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,137 @@
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"lyrics-library/internal/client/http/track"
+)
+
+const (
+	artistPlaceholder = "{artist}"
+	titlePlaceholder  = "{title}"
+)
+
+// Client fetches lyrics from any API answering GET requests with json
+type Client struct {
+	log         *slog.Logger
+	client      *http.Client
+	urlTemplate string
+	lyricsPath  []string
+	authHeader  string
+	authToken   string
+}
+
+// New returns a client for urlTemplate with {artist} and {title} placeholders.
+// lyricsField is a dot separated path to the lyrics in the response body,
+// authHeader is sent with authToken if it's not empty
+func New(log *slog.Logger, urlTemplate, lyricsField, authHeader, authToken string) *Client {
+	return &Client{
+		log:         log,
+		client:      &http.Client{},
+		urlTemplate: urlTemplate,
+		lyricsPath:  strings.Split(lyricsField, "."),
+		authHeader:  authHeader,
+		authToken:   authToken,
+	}
+}
+
+func (c *Client) Lyrics(ctx context.Context, artist, title string) ([]string, error) {
+	const op = "client.http.track.jsonapi.Lyrics"
+
+	log := c.log.With(slog.String("op", op),
+		slog.String("artist", artist),
+		slog.String("title", title),
+	)
+
+	log.Info("Fetching track")
+
+	apiURL := strings.NewReplacer(
+		artistPlaceholder, url.PathEscape(artist),
+		titlePlaceholder, url.PathEscape(title),
+	).Replace(c.urlTemplate)
+
+	log.Debug("Api URL", slog.String("url", apiURL))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if c.authHeader != "" {
+		req.Header.Set(c.authHeader, c.authToken)
+	}
+
+	lyrics, err := c.doAPIRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("track fetched successfully")
+
+	return track.FormatLyrics(lyrics), nil
+}
+
+func (c *Client) doAPIRequest(req *http.Request) (string, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	c.log.Debug("api response status", slog.String("status", resp.Status))
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", track.ErrLyricsNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+
+	var body any
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", err
+	}
+
+	lyrics := lookup(body, c.lyricsPath)
+	if strings.TrimSpace(lyrics) == "" {
+		return "", track.ErrLyricsNotFound
+	}
+
+	return lyrics, nil
+}
+
+// lookup follows path through json objects, an array of strings at the end is joined by lines
+func lookup(value any, path []string) string {
+	for _, key := range path {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		value = object[key]
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case []any:
+		lines := make([]string, 0, len(v))
+		for _, line := range v {
+			if s, ok := line.(string); ok {
+				lines = append(lines, s)
+			}
+		}
+
+		return strings.Join(lines, "\n")
+	}
+
+	return ""
+}
@@ -0,0 +1,85 @@
+package jsonapi
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/client/http/track"
+)
+
+func TestClient_Lyrics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		lyricsField    string
+		status         int
+		body           string
+		expectedLyrics []string
+		expectedError  error
+	}{
+		{
+			name:           "nested string",
+			lyricsField:    "data.lyrics",
+			status:         http.StatusOK,
+			body:           `{"data":{"lyrics":"first line\r\n\r\nsecond line"}}`,
+			expectedLyrics: []string{"first line", "", "second line"},
+		},
+		{
+			name:           "array of lines",
+			lyricsField:    "lines",
+			status:         http.StatusOK,
+			body:           `{"lines":["first line","second line"]}`,
+			expectedLyrics: []string{"first line", "second line"},
+		},
+		{
+			name:          "missing field",
+			lyricsField:   "lyrics",
+			status:        http.StatusOK,
+			body:          `{"error":"no lyrics"}`,
+			expectedError: track.ErrLyricsNotFound,
+		},
+		{
+			name:          "not found status",
+			lyricsField:   "lyrics",
+			status:        http.StatusNotFound,
+			body:          `{}`,
+			expectedError: track.ErrLyricsNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/AC%2FDC/Back%20in%20Black", r.URL.EscapedPath())
+				assert.Equal(t, "secret", r.Header.Get("X-Api-Key"))
+
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+			defer srv.Close()
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+			c := New(log, srv.URL+"/v1/{artist}/{title}", tt.lyricsField, "X-Api-Key", "secret")
+
+			lyrics, err := c.Lyrics(context.Background(), "AC/DC", "Back in Black")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, lyrics)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedLyrics, lyrics)
+		})
+	}
+}
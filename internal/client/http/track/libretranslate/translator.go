@@ -0,0 +1,163 @@
+package libretranslate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+
+	apiClient "lyrics-library/internal/client"
+	"lyrics-library/internal/client/http/track"
+)
+
+// LibreTranslate translates an array of texts in q into an array in translatedText
+const (
+	maxLines = 100
+	maxChars = 10000
+)
+
+type Request struct {
+	Q      []string `json:"q"`
+	Source string   `json:"source"`
+	Target string   `json:"target"`
+	Format string   `json:"format"`
+	APIKey string   `json:"api_key,omitempty"`
+}
+
+type Response struct {
+	TranslatedText []string `json:"translatedText"`
+	Error          string   `json:"error"`
+}
+
+// Client translates with any LibreTranslate compatible /translate endpoint,
+// apiKey may be empty for self-hosted instances
+type Client struct {
+	log    *slog.Logger
+	client *http.Client
+	apiKey string
+	apiURL string
+}
+
+func New(log *slog.Logger,
+	apiKey, apiURL string,
+) *Client {
+	return &Client{
+		log:    log,
+		client: &http.Client{},
+		apiKey: apiKey,
+		apiURL: apiURL,
+	}
+}
+
+func (c *Client) TranslateLyrics(
+	ctx context.Context,
+	lyrics []string,
+	targetLang string,
+) ([]string, error) {
+	const op = "client.http.track.libretranslate.TranslateLyrics"
+
+	log := c.log.With(slog.String("op", op), slog.String("target_lang", targetLang))
+
+	log.Info("translating track")
+
+	translation, err := track.TranslateLines(ctx, lyrics, maxLines, maxChars,
+		func(ctx context.Context, texts []string) ([]string, error) {
+			return c.translateTexts(ctx, log, texts, targetLang)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("track translated successfully")
+
+	return translation, nil
+}
+
+func (c *Client) translateTexts(
+	ctx context.Context,
+	log *slog.Logger,
+	texts []string,
+	targetLang string,
+) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiClient.RequestTimeout)
+	defer cancel()
+
+	req, err := c.buildAPIRequest(ctx, texts, targetLang)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.doAPIRequest(log, req)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("libretranslate response", slog.Any("response", res))
+
+	return res.TranslatedText, nil
+}
+
+func (c *Client) buildAPIRequest(
+	ctx context.Context,
+	texts []string,
+	targetLang string,
+) (*http.Request, error) {
+	reqBody, err := json.Marshal(Request{
+		Q:      texts,
+		Source: "auto",
+		Target: targetLang,
+		Format: "text",
+		APIKey: c.apiKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.apiURL,
+		bytes.NewBuffer(reqBody),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	return req, nil
+}
+
+func (c *Client) doAPIRequest(log *slog.Logger, req *http.Request) (*Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	log.Debug("response status", slog.String("status", resp.Status))
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var res Response
+	if err := json.Unmarshal(body, &res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: %s", track.ErrFailedTranslateLyrics, resp.Status)
+		}
+
+		return nil, err
+	}
+
+	if res.Error != "" || resp.StatusCode != http.StatusOK {
+		log.Error("libretranslate error", slog.String("error", res.Error))
+
+		return nil, fmt.Errorf("%w: %s: %s", track.ErrFailedTranslateLyrics, resp.Status, res.Error)
+	}
+
+	return &res, nil
+}
@@ -1,7 +1,9 @@
 package track
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -10,6 +12,8 @@ var (
 	ErrFailedTranslateLyrics = errors.New("failed translate track")
 )
 
+// FormatLyrics splits lyrics into trimmed lines. Stanzas stay separated by
+// a single empty line, leading and trailing empty lines are dropped
 func FormatLyrics(lyrics string) []string {
 	normalized := strings.ReplaceAll(lyrics, "\r\n", "\n")
 
@@ -17,10 +21,85 @@ func FormatLyrics(lyrics string) []string {
 
 	result := make([]string, 0, len(lines))
 	for _, line := range lines {
-		if trimmed := strings.TrimSpace(line); trimmed != "" {
-			result = append(result, trimmed)
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" && (len(result) == 0 || result[len(result)-1] == "") {
+			continue
 		}
+
+		result = append(result, trimmed)
+	}
+
+	if len(result) > 0 && result[len(result)-1] == "" {
+		result = result[:len(result)-1]
 	}
 
 	return result
 }
+
+// TranslateFunc translates every text separately and returns the translations in the same order
+type TranslateFunc func(ctx context.Context, texts []string) ([]string, error)
+
+// TranslateLines translates lyrics line by line so the translation has the same
+// length as lyrics. Empty lines aren't sent and stay empty, the rest is sent in
+// chunks of at most maxLines lines and maxChars characters
+func TranslateLines(
+	ctx context.Context,
+	lyrics []string,
+	maxLines, maxChars int,
+	translate TranslateFunc,
+) ([]string, error) {
+	translation := make([]string, len(lyrics))
+
+	var (
+		chunk     []string
+		positions []int
+		chars     int
+	)
+
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+
+		translated, err := translate(ctx, chunk)
+		if err != nil {
+			return err
+		}
+
+		if len(translated) != len(chunk) {
+			return fmt.Errorf("%w: sent %d lines, got %d",
+				ErrFailedTranslateLyrics, len(chunk), len(translated))
+		}
+
+		for i, pos := range positions {
+			translation[pos] = strings.TrimSpace(translated[i])
+		}
+
+		chunk, positions, chars = nil, nil, 0
+
+		return nil
+	}
+
+	for i, line := range lyrics {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if len(chunk) == maxLines || (len(chunk) > 0 && chars+len(line) > maxChars) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+
+		chunk = append(chunk, line)
+		positions = append(positions, i)
+		chars += len(line)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return translation, nil
+}
@@ -0,0 +1,54 @@
+package track
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatLyrics(t *testing.T) {
+	t.Parallel()
+
+	lyrics := "\r\n  [Verse 1]\r\nfirst line \r\n\r\n\r\n  second line\n\n"
+
+	assert.Equal(t, []string{"[Verse 1]", "first line", "", "second line"}, FormatLyrics(lyrics))
+}
+
+func TestTranslateLines(t *testing.T) {
+	t.Parallel()
+
+	lyrics := []string{"one", "two", "", "three", "four", "five"}
+
+	var chunks [][]string
+
+	translation, err := TranslateLines(context.Background(), lyrics, 2, 8,
+		func(_ context.Context, texts []string) ([]string, error) {
+			chunks = append(chunks, texts)
+
+			translated := make([]string, len(texts))
+			for i, text := range texts {
+				translated[i] = strings.ToUpper(text)
+			}
+
+			return translated, nil
+		},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"ONE", "TWO", "", "THREE", "FOUR", "FIVE"}, translation)
+	assert.Equal(t, [][]string{{"one", "two"}, {"three"}, {"four", "five"}}, chunks)
+}
+
+func TestTranslateLines_LengthMismatch(t *testing.T) {
+	t.Parallel()
+
+	_, err := TranslateLines(context.Background(), []string{"one", "two"}, 10, 100,
+		func(_ context.Context, texts []string) ([]string, error) {
+			return []string{strings.Join(texts, "\n")}, nil
+		},
+	)
+
+	assert.ErrorIs(t, err, ErrFailedTranslateLyrics)
+}
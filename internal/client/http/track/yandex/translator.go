@@ -8,75 +8,106 @@ import (
 	"io"
 	"log/slog"
 	"net/http"
-	"strings"
 
 	apiClient "lyrics-library/internal/client"
 	"lyrics-library/internal/client/http/track"
 )
 
+// Translate API accepts up to 10000 characters per request
+const (
+	maxLines = 100
+	maxChars = 9000
+)
+
 type Response struct {
 	Translations []struct {
 		Text string `json:"text"`
 	} `json:"translations"`
+	// Code and Message are set instead of translations if the request failed
+	Code    int    `json:"code"`
+	Message string `json:"message"`
 }
 
 type Client struct {
-	log        *slog.Logger
-	client     *http.Client
-	apiKey     string
-	apiURL     string
-	targetLang string
+	log    *slog.Logger
+	client *http.Client
+	apiKey string
+	apiURL string
 }
 
 func New(log *slog.Logger,
-	apiKey, apiURL, targetLang string,
+	apiKey, apiURL string,
 ) *Client {
 	return &Client{
-		log:        log,
-		client:     &http.Client{},
-		apiKey:     apiKey,
-		apiURL:     apiURL,
-		targetLang: targetLang,
+		log:    log,
+		client: &http.Client{},
+		apiKey: apiKey,
+		apiURL: apiURL,
 	}
 }
 
-func (c *Client) TranslateLyrics(ctx context.Context, lyrics []string) ([]string, error) {
+func (c *Client) TranslateLyrics(
+	ctx context.Context,
+	lyrics []string,
+	targetLang string,
+) ([]string, error) {
 	const op = "service.api.yandex.TranslateLyrics"
 
-	log := c.log.With(slog.String("op", op))
+	log := c.log.With(slog.String("op", op), slog.String("target_lang", targetLang))
 
 	log.Info("translating track")
 
+	translation, err := track.TranslateLines(ctx, lyrics, maxLines, maxChars,
+		func(ctx context.Context, texts []string) ([]string, error) {
+			return c.translateTexts(ctx, log, texts, targetLang)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("track translated successfully")
+
+	return translation, nil
+}
+
+func (c *Client) translateTexts(
+	ctx context.Context,
+	log *slog.Logger,
+	texts []string,
+	targetLang string,
+) ([]string, error) {
 	ctx, cancel := context.WithTimeout(ctx, apiClient.RequestTimeout)
 	defer cancel()
 
-	req, err := c.buildAPIRequest(ctx, lyrics)
+	req, err := c.buildAPIRequest(ctx, texts, targetLang)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, err
 	}
 
 	res, err := c.doAPIRequest(log, req)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, err
 	}
 
 	log.Debug("yandex translator response", slog.Any("response", res))
 
-	if len(res.Translations) == 0 {
-		return nil, track.ErrFailedTranslateLyrics
+	translations := make([]string, len(res.Translations))
+	for i, t := range res.Translations {
+		translations[i] = t.Text
 	}
 
-	formatted := track.FormatLyrics(res.Translations[0].Text)
-
-	log.Info("track translated successfully")
-
-	return formatted, nil
+	return translations, nil
 }
 
-func (c *Client) buildAPIRequest(ctx context.Context, lyrics []string) (*http.Request, error) {
+func (c *Client) buildAPIRequest(
+	ctx context.Context,
+	texts []string,
+	targetLang string,
+) (*http.Request, error) {
 	requestData := map[string]interface{}{
-		"texts":              []string{strings.Join(lyrics, "\n")},
-		"targetLanguageCode": c.targetLang,
+		"texts":              texts,
+		"targetLanguageCode": targetLang,
 	}
 
 	reqBody, err := json.Marshal(requestData)
@@ -114,8 +145,21 @@ func (c *Client) doAPIRequest(log *slog.Logger, req *http.Request) (*Response, e
 
 	var res Response
 	if err := json.Unmarshal(body, &res); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("%w: %s", track.ErrFailedTranslateLyrics, resp.Status)
+		}
+
 		return nil, err
 	}
 
+	if res.Message != "" || resp.StatusCode != http.StatusOK {
+		log.Error("yandex translator error",
+			slog.Int("code", res.Code),
+			slog.String("message", res.Message),
+		)
+
+		return nil, fmt.Errorf("%w: %s: %s", track.ErrFailedTranslateLyrics, resp.Status, res.Message)
+	}
+
 	return &res, nil
 }
@@ -0,0 +1,28 @@
+package translator
+
+import (
+	"context"
+	"fmt"
+)
+
+// Noop returns the lyrics as they are, for running without a translation api
+type Noop struct{}
+
+func (Noop) TranslateLyrics(_ context.Context, lyrics []string, _ string) ([]string, error) {
+	return append([]string(nil), lyrics...), nil
+}
+
+// Fake marks every line with the target language, so the same input
+// always gives the same translation in tests and local runs
+type Fake struct{}
+
+func (Fake) TranslateLyrics(_ context.Context, lyrics []string, targetLang string) ([]string, error) {
+	translation := make([]string, len(lyrics))
+	for i, line := range lyrics {
+		if line != "" {
+			translation[i] = fmt.Sprintf("[%s] %s", targetLang, line)
+		}
+	}
+
+	return translation, nil
+}
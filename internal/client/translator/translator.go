@@ -0,0 +1,112 @@
+package translator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"lyrics-library/internal/client/http/track/deepl"
+	"lyrics-library/internal/client/http/track/libretranslate"
+	"lyrics-library/internal/client/http/track/yandex"
+	"lyrics-library/internal/config"
+)
+
+const (
+	ProviderYandex         = "yandex"
+	ProviderLibreTranslate = "libretranslate"
+	ProviderDeepL          = "deepl"
+	ProviderNoop           = "noop"
+	ProviderFake           = "fake"
+)
+
+var (
+	ErrUnknownProvider = errors.New("unknown translator provider")
+	ErrMissingKey      = errors.New("translator api key is required")
+	ErrMissingURL      = errors.New("translator api url is required")
+)
+
+type Translator interface {
+	TranslateLyrics(ctx context.Context, lyrics []string, targetLang string) ([]string, error)
+}
+
+// Factory builds a translator from the TRANSLATOR_API_ config
+type Factory func(log *slog.Logger, cfg config.TranslatorAPIConfig) (Translator, error)
+
+var registry = map[string]Factory{
+	ProviderYandex: func(log *slog.Logger, cfg config.TranslatorAPIConfig) (Translator, error) {
+		if err := requireCredentials(cfg, true); err != nil {
+			return nil, err
+		}
+
+		return yandex.New(log, cfg.Key, cfg.URL), nil
+	},
+	ProviderLibreTranslate: func(log *slog.Logger, cfg config.TranslatorAPIConfig) (Translator, error) {
+		if err := requireCredentials(cfg, false); err != nil {
+			return nil, err
+		}
+
+		return libretranslate.New(log, cfg.Key, cfg.URL), nil
+	},
+	ProviderDeepL: func(log *slog.Logger, cfg config.TranslatorAPIConfig) (Translator, error) {
+		if err := requireCredentials(cfg, true); err != nil {
+			return nil, err
+		}
+
+		return deepl.New(log, cfg.Key, cfg.URL), nil
+	},
+	ProviderNoop: func(*slog.Logger, config.TranslatorAPIConfig) (Translator, error) {
+		return Noop{}, nil
+	},
+	ProviderFake: func(*slog.Logger, config.TranslatorAPIConfig) (Translator, error) {
+		return Fake{}, nil
+	},
+}
+
+// New returns the translator selected by cfg.Provider
+func New(log *slog.Logger, cfg config.TranslatorAPIConfig) (Translator, error) {
+	const op = "client.translator.New"
+
+	name := strings.ToLower(strings.TrimSpace(cfg.Provider))
+
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w: %q, expected one of %s",
+			op, ErrUnknownProvider, cfg.Provider, strings.Join(Providers(), ", "))
+	}
+
+	t, err := factory(log, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", op, name, err)
+	}
+
+	log.Info("translator selected", slog.String("provider", name))
+
+	return t, nil
+}
+
+// Providers returns the registered provider names
+func Providers() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func requireCredentials(cfg config.TranslatorAPIConfig, keyRequired bool) error {
+	if cfg.URL == "" {
+		return ErrMissingURL
+	}
+
+	if keyRequired && cfg.Key == "" {
+		return ErrMissingKey
+	}
+
+	return nil
+}
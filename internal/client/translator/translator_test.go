@@ -0,0 +1,196 @@
+package translator
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lyrics-library/internal/client/http/track"
+	"lyrics-library/internal/config"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name          string
+		cfg           config.TranslatorAPIConfig
+		expectedError error
+	}{
+		{
+			name: "yandex",
+			cfg:  config.TranslatorAPIConfig{Provider: "Yandex", Key: "key", URL: "http://localhost"},
+		},
+		{
+			name:          "yandex without key",
+			cfg:           config.TranslatorAPIConfig{Provider: ProviderYandex, URL: "http://localhost"},
+			expectedError: ErrMissingKey,
+		},
+		{
+			name: "libretranslate without key",
+			cfg:  config.TranslatorAPIConfig{Provider: ProviderLibreTranslate, URL: "http://localhost"},
+		},
+		{
+			name:          "deepl without url",
+			cfg:           config.TranslatorAPIConfig{Provider: ProviderDeepL, Key: "key"},
+			expectedError: ErrMissingURL,
+		},
+		{
+			name: "fake",
+			cfg:  config.TranslatorAPIConfig{Provider: ProviderFake},
+		},
+		{
+			name:          "unknown",
+			cfg:           config.TranslatorAPIConfig{Provider: "google"},
+			expectedError: ErrUnknownProvider,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tr, err := New(log, tt.cfg)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, tr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotNil(t, tr)
+		})
+	}
+}
+
+func TestTranslateLyrics(t *testing.T) {
+	t.Parallel()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	lyrics := []string{"first line", "", "second line"}
+
+	tests := []struct {
+		name                string
+		provider            string
+		status              int
+		response            string
+		expectedRequest     string
+		expectedTranslation []string
+		expectedError       error
+	}{
+		{
+			name:                "yandex",
+			provider:            ProviderYandex,
+			status:              http.StatusOK,
+			response:            `{"translations":[{"text":"первая строка"},{"text":"вторая строка"}]}`,
+			expectedRequest:     `{"texts":["first line","second line"],"targetLanguageCode":"ru"}`,
+			expectedTranslation: []string{"первая строка", "", "вторая строка"},
+		},
+		{
+			name:          "yandex error payload",
+			provider:      ProviderYandex,
+			status:        http.StatusUnauthorized,
+			response:      `{"code":16,"message":"Unknown api key","details":[]}`,
+			expectedError: track.ErrFailedTranslateLyrics,
+		},
+		{
+			name:                "libretranslate",
+			provider:            ProviderLibreTranslate,
+			status:              http.StatusOK,
+			response:            `{"translatedText":["первая строка","вторая строка"]}`,
+			expectedRequest:     `{"q":["first line","second line"],"source":"auto","target":"ru","format":"text","api_key":"key"}`,
+			expectedTranslation: []string{"первая строка", "", "вторая строка"},
+		},
+		{
+			name:          "libretranslate error payload",
+			provider:      ProviderLibreTranslate,
+			status:        http.StatusBadRequest,
+			response:      `{"error":"ru is not supported"}`,
+			expectedError: track.ErrFailedTranslateLyrics,
+		},
+		{
+			name:                "deepl",
+			provider:            ProviderDeepL,
+			status:              http.StatusOK,
+			response:            `{"translations":[{"detected_source_language":"EN","text":"первая строка"},{"detected_source_language":"EN","text":"вторая строка"}]}`,
+			expectedRequest:     `{"text":["first line","second line"],"target_lang":"RU"}`,
+			expectedTranslation: []string{"первая строка", "", "вторая строка"},
+		},
+		{
+			name:          "deepl error payload",
+			provider:      ProviderDeepL,
+			status:        http.StatusForbidden,
+			response:      `{"message":"Wrong endpoint"}`,
+			expectedError: track.ErrFailedTranslateLyrics,
+		},
+		{
+			name:          "yandex lines lost",
+			provider:      ProviderYandex,
+			status:        http.StatusOK,
+			response:      `{"translations":[{"text":"первая строка\nвторая строка"}]}`,
+			expectedError: track.ErrFailedTranslateLyrics,
+		},
+		{
+			name:                "noop",
+			provider:            ProviderNoop,
+			expectedTranslation: lyrics,
+		},
+		{
+			name:                "fake",
+			provider:            ProviderFake,
+			expectedTranslation: []string{"[ru] first line", "", "[ru] second line"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				if tt.expectedRequest != "" {
+					assert.JSONEq(t, tt.expectedRequest, string(body))
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.response)
+			}))
+			defer srv.Close()
+
+			tr, err := New(log, config.TranslatorAPIConfig{
+				Provider: tt.provider,
+				Key:      "key",
+				URL:      srv.URL,
+			})
+			require.NoError(t, err)
+
+			translation, err := tr.TranslateLyrics(context.Background(), lyrics, "ru")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, translation)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTranslation, translation)
+		})
+	}
+}
+
+func TestProviders(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"deepl", "fake", "libretranslate", "noop", "yandex"}, Providers())
+}
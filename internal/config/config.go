@@ -1,8 +1,12 @@
 package config
 
 import (
+	"errors"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -14,8 +18,11 @@ type Config struct {
 	DB            DBConfig            `env-prefix:"DB_" env-required:"true"`
 	Redis         RedisConfig         `env-prefix:"REDIS_" env-required:"true"`
 	Auth          AuthConfig          `env-prefix:"AUTH_" env-required:"true"`
+	Cookie        CookieConfig        `env-prefix:"COOKIE_"`
 	LyricsAPI     LyricsAPIConfig     `env-prefix:"LYRICS_API_" env-required:"true"`
-	TranslatorAPI TranslatorAPIConfig `env-prefix:"TRANSLATOR_API_" env-required:"true"`
+	TranslatorAPI TranslatorAPIConfig `env-prefix:"TRANSLATOR_API_"`
+	Jobs          JobsConfig          `env-prefix:"JOBS_"`
+	Batch         BatchConfig         `env-prefix:"BATCH_"`
 }
 
 type HTTPServerConfig struct {
@@ -39,22 +46,196 @@ type RedisConfig struct {
 	Port       string `env:"PORT" env-default:"6379"`
 	DockerPort string `env:"DOCKER_PORT" env-default:"6379"`
 	Password   string `env:"PASSWORD" env-required:"true"`
+	// KeyVersion prefixes every key, changing it abandons entries in an old format
+	KeyVersion string          `env:"KEY_VERSION" env-default:"v1"`
+	TTL        RedisTTLConfig  `env-prefix:"TTL_"`
+	Lock       RedisLockConfig `env-prefix:"LOCK_"`
+}
+
+// RedisTTLConfig sets expiry per key type, zero keeps entries until evicted.
+// NotFound is the expiry of cached "not found" results of tracks, artists and lyrics providers.
+// Every expiry is extended by a random part of up to Jitter of it
+type RedisTTLConfig struct {
+	Track        time.Duration `env:"TRACK" env-default:"24h"`
+	ArtistTracks time.Duration `env:"ARTIST_TRACKS" env-default:"1h"`
+	NotFound     time.Duration `env:"NOT_FOUND" env-default:"5m"`
+	Jitter       float64       `env:"JITTER" env-default:"0.1"`
+}
+
+// RedisLockConfig enables the lock app replicas take before fetching and translating
+// a new track. A replica waits up to Wait for the lock, the lock expires after TTL
+type RedisLockConfig struct {
+	Enabled       bool          `env:"ENABLED" env-default:"false"`
+	TTL           time.Duration `env:"TTL" env-default:"30s"`
+	Wait          time.Duration `env:"WAIT" env-default:"10s"`
+	RetryInterval time.Duration `env:"RETRY_INTERVAL" env-default:"100ms"`
+}
+
+func (c RedisLockConfig) validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.TTL <= 0 || c.Wait <= 0 || c.RetryInterval <= 0 {
+		return errors.New("ttl, wait and retry interval must be positive")
+	}
+
+	return nil
 }
 
 type AuthConfig struct {
 	Host    string `env:"HOST" env-default:"localhost"`
 	Port    string `env:"PORT" env-default:"44044"`
 	Retries int    `env:"RETRIES" env-default:"5"`
+	// RolesSource is table to read roles from the user_roles table
+	// or sso to read the roles claim of the token
+	RolesSource string               `env:"ROLES_SOURCE" env-default:"table"`
+	Breaker     AuthBreakerConfig    `env-prefix:"BREAKER_"`
+	TokenCache  AuthTokenCacheConfig `env-prefix:"TOKEN_CACHE_"`
+	Verifier    AuthVerifierConfig   `env-prefix:"VERIFIER_"`
+}
+
+// AuthBreakerConfig stops calling the auth service after Failures failed calls
+// in a row, a probe call is let through once Cooldown passes. Zero Failures disables it
+type AuthBreakerConfig struct {
+	Failures int           `env:"FAILURES" env-default:"5"`
+	Cooldown time.Duration `env:"COOLDOWN" env-default:"10s"`
+}
+
+// AuthTokenCacheConfig keeps validated tokens for up to TTL but never past their expiry.
+// Backend is memory for an LRU of at most Size tokens per replica, redis to share
+// tokens between replicas or none
+type AuthTokenCacheConfig struct {
+	Backend string        `env:"BACKEND" env-default:"memory"`
+	TTL     time.Duration `env:"TTL" env-default:"1m"`
+	Size    int           `env:"SIZE" env-default:"10000"`
+}
+
+// AuthVerifierConfig selects how tokens are verified: grpc asks the auth service,
+// local checks the signature with Secret or keys of the JWKS read from JWKSFile
+// or fetched from JWKSURL and falls back to the auth service for unknown key ids.
+// Keys of JWKSURL are fetched again on an unknown key id at most every JWKSRefresh.
+// Empty Issuer and Audience aren't checked, Leeway allows for clock skew
+type AuthVerifierConfig struct {
+	Mode        string        `env:"MODE" env-default:"grpc"`
+	Secret      string        `env:"SECRET"`
+	JWKSFile    string        `env:"JWKS_FILE"`
+	JWKSURL     string        `env:"JWKS_URL"`
+	JWKSRefresh time.Duration `env:"JWKS_REFRESH" env-default:"5m"`
+	Issuer      string        `env:"ISSUER"`
+	Audience    string        `env:"AUDIENCE"`
+	Leeway      time.Duration `env:"LEEWAY" env-default:"30s"`
+}
+
+// CookieConfig sets up the token cookie set on login. SameSite is one of
+// lax, strict or none, browsers drop SameSite=None cookies that aren't Secure
+type CookieConfig struct {
+	Domain   string `env:"DOMAIN" env-default:"localhost"`
+	Secure   bool   `env:"SECURE" env-default:"false"`
+	SameSite string `env:"SAME_SITE" env-default:"lax"`
+}
+
+// SameSiteMode converts SameSite to the cookie attribute, lax is used for unknown values
+func (c CookieConfig) SameSiteMode() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
+
+func (c CookieConfig) validate() error {
+	switch strings.ToLower(c.SameSite) {
+	case "lax", "strict":
+	case "none":
+		if !c.Secure {
+			return errors.New("same site none requires a secure cookie")
+		}
+	default:
+		return fmt.Errorf("unknown same site mode %q", c.SameSite)
+	}
+
+	return nil
 }
 
 type TranslatorAPIConfig struct {
-	Key        string `env:"KEY" env-required:"true"`
-	URL        string `env:"URL" env-required:"true"`
+	// Provider is one of yandex, libretranslate, deepl, noop, fake
+	Provider string `env:"PROVIDER" env-default:"yandex"`
+	Key      string `env:"KEY"`
+	URL      string `env:"URL"`
+	// TargetLang is used when a request doesn't specify the translation language
 	TargetLang string `env:"TARGET_LANG" env-default:"ru"`
 }
 
 type LyricsAPIConfig struct {
-	URL string `env:"URL" env-required:"true"`
+	URL     string        `env:"URL" env-required:"true"`
+	Timeout time.Duration `env:"TIMEOUT" env-default:"10s"`
+	// Providers are tried in the listed order: lyricsovh, http, local
+	Providers []string `env:"PROVIDERS" env-separator:"," env-default:"lyricsovh"`
+	// Parallel queries all providers at once and takes the first found lyrics
+	Parallel bool              `env:"PARALLEL" env-default:"false"`
+	HTTP     LyricsHTTPConfig  `env-prefix:"HTTP_"`
+	Local    LyricsLocalConfig `env-prefix:"LOCAL_"`
+}
+
+type LyricsHTTPConfig struct {
+	// URL is a template with {artist} and {title} placeholders
+	URL string `env:"URL"`
+	// LyricsField is a dot separated path to the lyrics in the response body
+	LyricsField string        `env:"LYRICS_FIELD" env-default:"lyrics"`
+	AuthHeader  string        `env:"AUTH_HEADER"`
+	AuthToken   string        `env:"AUTH_TOKEN"`
+	Timeout     time.Duration `env:"TIMEOUT" env-default:"10s"`
+}
+
+type LyricsLocalConfig struct {
+	Dir     string        `env:"DIR"`
+	Timeout time.Duration `env:"TIMEOUT" env-default:"2s"`
+}
+
+// JobsConfig sets up background track imports. Workers poll for pending jobs
+// every PollInterval, a job running for longer than StaleAfter is considered
+// abandoned by a stopped replica and is run again
+type JobsConfig struct {
+	Workers      int           `env:"WORKERS" env-default:"4"`
+	PollInterval time.Duration `env:"POLL_INTERVAL" env-default:"1s"`
+	StaleAfter   time.Duration `env:"STALE_AFTER" env-default:"5m"`
+}
+
+func (c JobsConfig) validate() error {
+	if c.Workers <= 0 {
+		return errors.New("workers must be positive")
+	}
+
+	if c.PollInterval <= 0 || c.StaleAfter <= 0 {
+		return errors.New("poll interval and stale after must be positive")
+	}
+
+	return nil
+}
+
+// BatchConfig limits bulk imports, at most Concurrency tracks of a batch
+// are imported at once and a request may hold at most MaxItems tracks.
+// Batches of more than SyncMaxItems tracks are always queued as import jobs
+type BatchConfig struct {
+	Concurrency  int `env:"CONCURRENCY" env-default:"4"`
+	MaxItems     int `env:"MAX_ITEMS" env-default:"500"`
+	SyncMaxItems int `env:"SYNC_MAX_ITEMS" env-default:"20"`
+}
+
+func (c BatchConfig) validate() error {
+	if c.Concurrency <= 0 || c.MaxItems <= 0 || c.SyncMaxItems <= 0 {
+		return errors.New("concurrency, max items and sync max items must be positive")
+	}
+
+	if c.SyncMaxItems > c.MaxItems {
+		return errors.New("sync max items must not exceed max items")
+	}
+
+	return nil
 }
 
 // MustLoad Load config file and panic if error occurs
@@ -74,6 +255,22 @@ func MustLoad() *Config {
 		panic("failed to read config: " + err.Error())
 	}
 
+	if err := cfg.Cookie.validate(); err != nil {
+		panic("invalid cookie config: " + err.Error())
+	}
+
+	if err := cfg.Redis.Lock.validate(); err != nil {
+		panic("invalid redis lock config: " + err.Error())
+	}
+
+	if err := cfg.Jobs.validate(); err != nil {
+		panic("invalid jobs config: " + err.Error())
+	}
+
+	if err := cfg.Batch.validate(); err != nil {
+		panic("invalid batch config: " + err.Error())
+	}
+
 	return &cfg
 }
 
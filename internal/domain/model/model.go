@@ -1,8 +1,159 @@
 package model
 
+import "time"
+
 type Track struct {
-	Artist      string
-	Title       string
-	Lyrics      []string
-	Translation []string
+	UUID   string
+	UserID int64
+	Artist string
+	Title  string
+	Lyrics []Stanza
+	// Provider is the name of the lyrics source the track was fetched from
+	Provider string
+	// Translations of the lyrics keyed by target language code,
+	// every translation has the same stanzas as the lyrics
+	Translations map[string][]Stanza
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Stanza is a verse, chorus or any other block of lines,
+// Label holds the section marker like "Chorus" if the lyrics have one.
+// Times are the start times of Lines in time-synced lyrics, nil otherwise
+type Stanza struct {
+	Label string          `json:"label,omitempty"`
+	Lines []string        `json:"lines"`
+	Times []time.Duration `json:"times,omitempty"`
+}
+
+// SearchResult is a track matching a full text search query
+type SearchResult struct {
+	Track *Track
+	Rank  float64
+	// Snippet is the matching fragment with the found words highlighted
+	Snippet string
+	// MatchLang is the translation language the query was found in,
+	// empty if it was found in the original lyrics
+	MatchLang string
+}
+
+const (
+	SortByTitle     = "title"
+	SortByCreatedAt = "created_at"
+)
+
+// PageQuery selects one page of a track listing
+type PageQuery struct {
+	Limit  int
+	SortBy string
+	Desc   bool
+	// After is the cursor returned with the previous page, nil for the first page
+	After *Cursor
+	// Summary leaves out lyrics and translations
+	Summary bool
 }
+
+// Cursor points at the last track of a page by its sort key and uuid
+type Cursor struct {
+	SortBy string `json:"s"`
+	Desc   bool   `json:"d,omitempty"`
+	Key    string `json:"k"`
+	UUID   string `json:"u"`
+}
+
+type TrackPage struct {
+	Tracks []*Track
+	// Next is nil on the last page
+	Next *Cursor
+}
+
+// Suggestion is a stored track similar to a requested one, Score is in [0, 1]
+type Suggestion struct {
+	UUID   string
+	Artist string
+	Title  string
+	Score  float64
+}
+
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// Job is a track import run in background, TrackUUID is set once it's done
+// and Error once it's failed
+type Job struct {
+	ID        string
+	UserID    int64
+	Artist    string
+	Title     string
+	Lang      string
+	Status    string
+	Error     string
+	TrackUUID string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BatchItem is a track of a bulk import, empty Lang is the default language
+type BatchItem struct {
+	Artist string
+	Title  string
+	Lang   string
+}
+
+const (
+	BatchSaved             = "saved"
+	BatchExists            = "exists"
+	BatchNotFound          = "not_found"
+	BatchTranslationFailed = "translation_failed"
+	BatchFailed            = "failed"
+	BatchQueued            = "queued"
+)
+
+// BatchResult is the outcome of a bulk import item. TrackUUID is set for saved
+// and existing tracks, JobID for items queued as background jobs
+type BatchResult struct {
+	Item      BatchItem
+	Status    string
+	TrackUUID string
+	JobID     string
+}
+
+// ExportFilter selects tracks to export, zero fields don't filter
+type ExportFilter struct {
+	Artist string
+	UserID int64
+}
+
+// Collection is a named list of tracks ordered by the user. Slug is set while
+// the collection is shared read-only, Tracks are left without lyrics and
+// TrackCount is set when collections are listed without their tracks
+type Collection struct {
+	ID         string
+	UserID     int64
+	Name       string
+	Slug       string
+	Tracks     []*Track
+	TrackCount int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// APIKey is a long-lived key the user authenticates with instead of a token,
+// only Prefix of the key is kept readable. LastUsedAt is nil until the key is used
+// and is updated at most once a minute
+type APIKey struct {
+	ID         string
+	UserID     int64
+	Name       string
+	Prefix     string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// RoleAdmin may delete tracks of any user and run bulk operations
+// like importing playlists and exporting the whole library
+const RoleAdmin = "admin"
@@ -0,0 +1,40 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	b := New(2, 10*time.Second)
+	b.now = func() time.Time { return now }
+
+	assert.NoError(t, b.Allow())
+	b.Failure()
+	assert.NoError(t, b.Allow(), "a failure below the threshold keeps the breaker closed")
+	b.Success()
+
+	b.Failure()
+	assert.NoError(t, b.Allow(), "a success resets failures")
+	b.Failure()
+	assert.ErrorIs(t, b.Allow(), ErrOpen)
+
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, b.Allow(), "a probe is let through after cooldown")
+	assert.ErrorIs(t, b.Allow(), ErrOpen, "only one probe at a time")
+
+	b.Failure()
+	assert.ErrorIs(t, b.Allow(), ErrOpen, "a failed probe opens the breaker again")
+
+	now = now.Add(10 * time.Second)
+	assert.NoError(t, b.Allow())
+	b.Success()
+	assert.NoError(t, b.Allow())
+	assert.NoError(t, b.Allow())
+}
@@ -0,0 +1,61 @@
+package claims
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrMalformedToken = errors.New("malformed token")
+
+type payload struct {
+	Roles []string `json:"roles"`
+	Exp   int64    `json:"exp"`
+}
+
+// Roles returns the roles claim of a JWT. The signature isn't verified,
+// the token must be validated by the auth service first
+func Roles(token string) ([]string, error) {
+	p, err := decode(token)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Roles, nil
+}
+
+// Expiry returns the exp claim of a JWT, zero time if the token has none.
+// The signature isn't verified, the token must be validated by the auth service first
+func Expiry(token string) (time.Time, error) {
+	p, err := decode(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if p.Exp == 0 {
+		return time.Time{}, nil
+	}
+
+	return time.Unix(p.Exp, 0), nil
+}
+
+func decode(token string) (*payload, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	var p payload
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	return &p, nil
+}
@@ -0,0 +1,72 @@
+package claims
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func token(payload string) string {
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+}
+
+func TestRoles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedRoles []string
+		expectedError error
+	}{
+		{
+			name:          "roles claim",
+			token:         token(`{"uid":1,"roles":["admin","editor"]}`),
+			expectedRoles: []string{"admin", "editor"},
+		},
+		{
+			name:  "no roles claim",
+			token: token(`{"uid":1}`),
+		},
+		{
+			name:          "not a jwt",
+			token:         "token",
+			expectedError: ErrMalformedToken,
+		},
+		{
+			name:          "payload isn't json",
+			token:         token(`uid=1`),
+			expectedError: ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			roles, err := Roles(tt.token)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedRoles, roles)
+		})
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	t.Parallel()
+
+	exp, err := Expiry(token(`{"uid":1,"exp":1746100800}`))
+	assert.NoError(t, err)
+	assert.True(t, exp.Equal(time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)))
+
+	exp, err = Expiry(token(`{"uid":1}`))
+	assert.NoError(t, err)
+	assert.True(t, exp.IsZero())
+
+	_, err = Expiry("token")
+	assert.ErrorIs(t, err, ErrMalformedToken)
+}
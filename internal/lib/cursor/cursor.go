@@ -0,0 +1,44 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"lyrics-library/internal/domain/model"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Encode returns an opaque url safe cursor, empty for nil
+func Encode(c *model.Cursor) string {
+	if c == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Decode parses a cursor returned by Encode, nil is returned for an empty string
+func Decode(s string) (*model.Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c model.Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.UUID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,37 @@
+package cursor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lyrics-library/internal/domain/model"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	t.Parallel()
+
+	c := &model.Cursor{
+		SortBy: model.SortByCreatedAt,
+		Desc:   true,
+		Key:    "2025-05-01T12:00:00.123456Z",
+		UUID:   "e434dc13-ada5-4bde-b695-d97014dadebc",
+	}
+
+	decoded, err := Decode(Encode(c))
+	require.NoError(t, err)
+	assert.Equal(t, c, decoded)
+
+	assert.Empty(t, Encode(nil))
+
+	decoded, err = Decode("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	_, err = Decode("not a cursor!")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = Decode(Encode(&model.Cursor{SortBy: model.SortByTitle, Key: "Lucid Dreams"}))
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
@@ -0,0 +1,256 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/lyrics"
+)
+
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatTXT    = "txt"
+	FormatLRC    = "lrc"
+	FormatSRT    = "srt"
+	FormatASS    = "ass"
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+// Writer writes tracks one by one in an export format
+type Writer interface {
+	Write(t *model.Track) error
+	// Close flushes buffered output, the underlying writer is left open
+	Close() error
+}
+
+// Format is an export format, per track formats are written
+// as a zip archive with a file per track
+type Format struct {
+	Name        string
+	ContentType string
+	// Filename is the suggested name of the downloaded file
+	Filename  string
+	newWriter func(w io.Writer, lang string) Writer
+}
+
+var formats = map[string]Format{
+	FormatNDJSON: {
+		ContentType: "application/x-ndjson",
+		Filename:    "lyrics.ndjson",
+		newWriter:   newNDJSONWriter,
+	},
+	FormatCSV: {
+		ContentType: "text/csv; charset=utf-8",
+		Filename:    "lyrics.csv",
+		newWriter:   newCSVWriter,
+	},
+	FormatTXT: perTrack(FormatTXT, renderTXT),
+	FormatLRC: perTrack(FormatLRC, renderLRC),
+	FormatSRT: perTrack(FormatSRT, renderSRT),
+	FormatASS: perTrack(FormatASS, renderASS),
+}
+
+// Lookup returns the format by its name
+func Lookup(name string) (Format, error) {
+	f, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("%w: %s", ErrUnknownFormat, name)
+	}
+
+	f.Name = name
+
+	return f, nil
+}
+
+// Formats returns names of the supported formats in alphabetical order
+func Formats() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// NewWriter returns a writer of the format, lang selects the translation
+// written by formats holding a single one
+func (f Format) NewWriter(w io.Writer, lang string) Writer {
+	return f.newWriter(w, lang)
+}
+
+// record is a track in NDJSON with all its translations, enough to restore the library
+type record struct {
+	UUID         string                    `json:"uuid"`
+	Artist       string                    `json:"artist"`
+	Title        string                    `json:"title"`
+	Provider     string                    `json:"provider,omitempty"`
+	Lyrics       []model.Stanza            `json:"lyrics"`
+	Translations map[string][]model.Stanza `json:"translations"`
+	CreatedAt    time.Time                 `json:"created_at"`
+	UpdatedAt    time.Time                 `json:"updated_at"`
+}
+
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer, _ string) Writer {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	return &ndjsonWriter{enc: enc}
+}
+
+func (w *ndjsonWriter) Write(t *model.Track) error {
+	return w.enc.Encode(record{
+		UUID:         t.UUID,
+		Artist:       t.Artist,
+		Title:        t.Title,
+		Provider:     t.Provider,
+		Lyrics:       t.Lyrics,
+		Translations: t.Translations,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+	})
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
+
+var csvHeader = []string{
+	"uuid", "artist", "title", "provider", "lang", "lyrics", "translation", "created_at", "updated_at",
+}
+
+type csvWriter struct {
+	w           *csv.Writer
+	lang        string
+	wroteHeader bool
+}
+
+func newCSVWriter(w io.Writer, lang string) Writer {
+	return &csvWriter{w: csv.NewWriter(w), lang: lang}
+}
+
+func (w *csvWriter) Write(t *model.Track) error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+
+		w.wroteHeader = true
+	}
+
+	return w.w.Write([]string{
+		t.UUID,
+		csvText(t.Artist),
+		csvText(t.Title),
+		csvText(t.Provider),
+		w.lang,
+		csvText(strings.Join(lyrics.Flatten(t.Lyrics), "\n")),
+		csvText(strings.Join(lyrics.Flatten(t.Translations[w.lang]), "\n")),
+		t.CreatedAt.Format(time.RFC3339),
+		t.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+// csvText prefixes user edited text that a spreadsheet would run as a formula
+// with a quote, so the cell is shown as text when the export is opened
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func (w *csvWriter) Close() error {
+	if !w.wroteHeader {
+		if err := w.w.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+
+	w.w.Flush()
+
+	return w.w.Error()
+}
+
+// renderFunc renders a single track into a file of a per track format
+type renderFunc func(t *model.Track, lang string) []byte
+
+func perTrack(ext string, render renderFunc) Format {
+	return Format{
+		ContentType: "application/zip",
+		Filename:    "lyrics-" + ext + ".zip",
+		newWriter: func(w io.Writer, lang string) Writer {
+			return &zipWriter{
+				zw:     zip.NewWriter(w),
+				ext:    ext,
+				lang:   lang,
+				render: render,
+				names:  make(map[string]int),
+			}
+		},
+	}
+}
+
+type zipWriter struct {
+	zw     *zip.Writer
+	ext    string
+	lang   string
+	render renderFunc
+	// names counts files per name so tracks with the same name don't overwrite each other
+	names map[string]int
+}
+
+func (w *zipWriter) Write(t *model.Track) error {
+	name := fileName(t.Artist + " - " + t.Title)
+
+	w.names[name]++
+	if n := w.names[name]; n > 1 {
+		name = fmt.Sprintf("%s (%d)", name, n)
+	}
+
+	f, err := w.zw.CreateHeader(&zip.FileHeader{
+		Name:     name + "." + w.ext,
+		Method:   zip.Deflate,
+		Modified: t.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(w.render(t, w.lang))
+
+	return err
+}
+
+func (w *zipWriter) Close() error {
+	return w.zw.Close()
+}
+
+// fileName replaces characters not allowed in file names on common systems
+func fileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < ' ' || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+
+		return r
+	}, name)
+
+	return strings.Trim(name, " .")
+}
@@ -0,0 +1,224 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lyrics-library/internal/domain/model"
+)
+
+var createdAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func testTrack() *model.Track {
+	return &model.Track{
+		UUID:     "e434dc13-ada5-4bde-b695-d97014dadebc",
+		Artist:   "Juice WRLD",
+		Title:    "Lucid Dreams",
+		Provider: "lyricsovh",
+		Lyrics: []model.Stanza{
+			{Label: "Chorus", Lines: []string{"I still see your shadows", "In my room"}},
+			{Lines: []string{"{Outro}"}},
+		},
+		Translations: map[string][]model.Stanza{
+			"ru": {
+				{Label: "Chorus", Lines: []string{"Я все еще вижу твои тени", "В моей комнате"}},
+				{Lines: []string{""}},
+			},
+		},
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+	}
+}
+
+func write(t *testing.T, format string, tracks ...*model.Track) []byte {
+	t.Helper()
+
+	f, err := Lookup(format)
+	require.NoError(t, err)
+
+	var b bytes.Buffer
+
+	w := f.NewWriter(&b, "ru")
+	for _, track := range tracks {
+		require.NoError(t, w.Write(track))
+	}
+	require.NoError(t, w.Close())
+
+	return b.Bytes()
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	f, err := Lookup(FormatSRT)
+	assert.NoError(t, err)
+	assert.Equal(t, "srt", f.Name)
+	assert.Equal(t, "application/zip", f.ContentType)
+	assert.Equal(t, "lyrics-srt.zip", f.Filename)
+
+	_, err = Lookup("xml")
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	assert.Equal(t, []string{"ass", "csv", "lrc", "ndjson", "srt", "txt"}, Formats())
+}
+
+func TestNDJSON(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		`{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","provider":"lyricsovh",`+
+			`"lyrics":[{"label":"Chorus","lines":["I still see your shadows","In my room"]},{"lines":["{Outro}"]}],`+
+			`"translations":{"ru":[{"label":"Chorus","lines":["Я все еще вижу твои тени","В моей комнате"]},{"lines":[""]}]},`+
+			`"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`+"\n",
+		string(write(t, FormatNDJSON, testTrack())),
+	)
+}
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"uuid,artist,title,provider,lang,lyrics,translation,created_at,updated_at\n"+
+			"e434dc13-ada5-4bde-b695-d97014dadebc,Juice WRLD,Lucid Dreams,lyricsovh,ru,"+
+			"\"[Chorus]\nI still see your shadows\nIn my room\n\n{Outro}\","+
+			"\"[Chorus]\nЯ все еще вижу твои тени\nВ моей комнате\n\n\","+
+			"2025-05-01T12:00:00Z,2025-05-01T12:00:00Z\n",
+		string(write(t, FormatCSV, testTrack())),
+	)
+
+	assert.Equal(t, "uuid,artist,title,provider,lang,lyrics,translation,created_at,updated_at\n",
+		string(write(t, FormatCSV)))
+}
+
+func TestCSVFormulas(t *testing.T) {
+	t.Parallel()
+
+	track := testTrack()
+	track.Artist = "=HYPERLINK(\"http://evil\")"
+	track.Title = "+1"
+	track.Lyrics = []model.Stanza{{Lines: []string{"-2+3", "=cmd"}}}
+	track.Translations = map[string][]model.Stanza{"ru": {{Lines: []string{"@SUM(A1)", "строка"}}}}
+
+	assert.Equal(t,
+		"uuid,artist,title,provider,lang,lyrics,translation,created_at,updated_at\n"+
+			"e434dc13-ada5-4bde-b695-d97014dadebc,\"'=HYPERLINK(\"\"http://evil\"\")\",'+1,lyricsovh,ru,"+
+			"\"'-2+3\n=cmd\",\"'@SUM(A1)\nстрока\","+
+			"2025-05-01T12:00:00Z,2025-05-01T12:00:00Z\n",
+		string(write(t, FormatCSV, track)),
+	)
+
+	for _, s := range []string{"\tcell", "\rcell"} {
+		assert.Equal(t, "'"+s, csvText(s))
+	}
+	assert.Equal(t, "plain", csvText("plain"))
+	assert.Equal(t, "", csvText(""))
+}
+
+func TestPerTrackFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: FormatTXT,
+			expected: "Juice WRLD - Lucid Dreams\n\n" +
+				"[Chorus]\nI still see your shadows\nIn my room\n\n{Outro}\n\n" +
+				"[Translation: ru]\n\n" +
+				"[Chorus]\nЯ все еще вижу твои тени\nВ моей комнате\n\n\n",
+		},
+		{
+			format: FormatLRC,
+			expected: "[ar:Juice WRLD]\n[ti:Lucid Dreams]\n" +
+				"[00:00.00]I still see your shadows\n" +
+				"[00:03.00]In my room\n" +
+				"[00:07.00]{Outro}\n",
+		},
+		{
+			format: FormatSRT,
+			expected: "1\n00:00:00,000 --> 00:00:03,000\nI still see your shadows\nЯ все еще вижу твои тени\n\n" +
+				"2\n00:00:03,000 --> 00:00:06,000\nIn my room\nВ моей комнате\n\n" +
+				"3\n00:00:07,000 --> 00:00:10,000\n{Outro}\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+
+			files := unzip(t, write(t, tt.format, testTrack(), testTrack()))
+
+			assert.Equal(t, map[string]string{
+				"Juice WRLD - Lucid Dreams." + tt.format:     tt.expected,
+				"Juice WRLD - Lucid Dreams (2)." + tt.format: tt.expected,
+			}, files)
+		})
+	}
+}
+
+func TestRenderSynced(t *testing.T) {
+	t.Parallel()
+
+	track := testTrack()
+	track.Lyrics[0].Times = []time.Duration{1500 * time.Millisecond, 65250 * time.Millisecond}
+	track.Lyrics[1].Times = []time.Duration{70 * time.Second}
+
+	assert.Equal(t, "[ar:Juice WRLD]\n[ti:Lucid Dreams]\n"+
+		"[00:01.50]I still see your shadows\n"+
+		"[01:05.25]In my room\n"+
+		"[01:10.00]{Outro}\n",
+		string(renderLRC(track, "ru")),
+	)
+
+	assert.Equal(t, "1\n00:00:01,500 --> 00:01:05,250\nI still see your shadows\nЯ все еще вижу твои тени\n\n"+
+		"2\n00:01:05,250 --> 00:01:10,000\nIn my room\nВ моей комнате\n\n"+
+		"3\n00:01:10,000 --> 00:01:13,000\n{Outro}\n\n",
+		string(renderSRT(track, "ru")),
+	)
+}
+
+func TestRenderASS(t *testing.T) {
+	t.Parallel()
+
+	ass := string(renderASS(testTrack(), "ru"))
+
+	assert.Contains(t, ass, "Title: Juice WRLD - Lucid Dreams\n")
+	assert.Contains(t, ass, "Dialogue: 0,0:00:00.00,0:00:03.00,Original,,0,0,0,,I still see your shadows\n"+
+		"Dialogue: 0,0:00:00.00,0:00:03.00,Translation,,0,0,0,,Я все еще вижу твои тени\n")
+	assert.Contains(t, ass, "Dialogue: 0,0:00:07.00,0:00:10.00,Original,,0,0,0,,\\{Outro\\}\n")
+	assert.NotContains(t, ass, "0:00:07.00,0:00:10.00,Translation")
+}
+
+func TestFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "AC_DC - Back in Black_", fileName(" AC/DC - Back in Black?. "))
+}
+
+func unzip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+
+		files[f.Name] = string(content)
+	}
+
+	return files
+}
@@ -0,0 +1,161 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/lyrics"
+)
+
+// Lyrics without timing are shown line by line for lineDuration each with
+// stanzas separated by stanzaGap, the last time-synced line is shown for lineDuration
+const (
+	lineDuration = 3 * time.Second
+	stanzaGap    = time.Second
+)
+
+// cue is a lyrics line paired with its translation and shown from Start to End
+type cue struct {
+	Start       time.Duration
+	End         time.Duration
+	Original    string
+	Translation string
+}
+
+// timeline pairs every lyrics line with the line at the same position of the lang translation,
+// a time-synced line is shown until the next one starts
+func timeline(t *model.Track, lang string) []cue {
+	translation := t.Translations[lang]
+	synced := lyrics.Synced(t.Lyrics)
+
+	var (
+		cues []cue
+		at   time.Duration
+	)
+
+	for i, stanza := range t.Lyrics {
+		if i > 0 {
+			at += stanzaGap
+		}
+
+		for j, line := range stanza.Lines {
+			if synced {
+				at = stanza.Times[j]
+			}
+
+			c := cue{Start: at, End: at + lineDuration, Original: line}
+
+			if i < len(translation) && j < len(translation[i].Lines) {
+				c.Translation = translation[i].Lines[j]
+			}
+
+			cues = append(cues, c)
+			at += lineDuration
+		}
+	}
+
+	if synced {
+		for i := 0; i < len(cues)-1; i++ {
+			cues[i].End = cues[i+1].Start
+		}
+	}
+
+	return cues
+}
+
+// renderTXT writes the lyrics followed by the lang translation if the track has it
+func renderTXT(t *model.Track, lang string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%s - %s\n\n", t.Artist, t.Title)
+	b.WriteString(strings.Join(lyrics.Flatten(t.Lyrics), "\n"))
+	b.WriteString("\n")
+
+	if translation := t.Translations[lang]; len(translation) > 0 {
+		fmt.Fprintf(&b, "\n[Translation: %s]\n\n", lang)
+		b.WriteString(strings.Join(lyrics.Flatten(translation), "\n"))
+		b.WriteString("\n")
+	}
+
+	return b.Bytes()
+}
+
+func renderLRC(t *model.Track, _ string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "[ar:%s]\n[ti:%s]\n", t.Artist, t.Title)
+
+	for _, c := range timeline(t, "") {
+		fmt.Fprintf(&b, "[%02d:%05.2f]%s\n", int(c.Start.Minutes()), (c.Start % time.Minute).Seconds(), c.Original)
+	}
+
+	return b.Bytes()
+}
+
+// renderSRT writes a subtitle per line with the translation under the original
+func renderSRT(t *model.Track, lang string) []byte {
+	var b bytes.Buffer
+
+	for i, c := range timeline(t, lang) {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n", i+1, srtTime(c.Start), srtTime(c.End), c.Original)
+
+		if c.Translation != "" {
+			fmt.Fprintf(&b, "%s\n", c.Translation)
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.Bytes()
+}
+
+func srtTime(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d:%02d,%03d",
+		int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60, d.Milliseconds()%1000)
+}
+
+// assHeader styles the original above the smaller, italic translation at the bottom of the screen
+const assHeader = `[Script Info]
+Title: %s
+ScriptType: v4.00+
+WrapStyle: 0
+
+[V4+ Styles]
+Format: Name, Fontname, Fontsize, PrimaryColour, SecondaryColour, OutlineColour, BackColour, Bold, Italic, Underline, StrikeOut, ScaleX, ScaleY, Spacing, Angle, BorderStyle, Outline, Shadow, Alignment, MarginL, MarginR, MarginV, Encoding
+Style: Original,Arial,48,&H00FFFFFF,&H000000FF,&H00000000,&H64000000,0,0,0,0,100,100,0,0,1,2,0,2,10,10,70,1
+Style: Translation,Arial,40,&H0000FFFF,&H000000FF,&H00000000,&H64000000,0,-1,0,0,100,100,0,0,1,2,0,2,10,10,20,1
+
+[Events]
+Format: Layer, Start, End, Style, Name, MarginL, MarginR, MarginV, Effect, Text
+`
+
+// renderASS writes the original and the translation of a line as two events with their own styles
+func renderASS(t *model.Track, lang string) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, assHeader, assText(t.Artist+" - "+t.Title))
+
+	for _, c := range timeline(t, lang) {
+		fmt.Fprintf(&b, "Dialogue: 0,%s,%s,Original,,0,0,0,,%s\n", assTime(c.Start), assTime(c.End), assText(c.Original))
+
+		if c.Translation != "" {
+			fmt.Fprintf(&b, "Dialogue: 0,%s,%s,Translation,,0,0,0,,%s\n",
+				assTime(c.Start), assTime(c.End), assText(c.Translation))
+		}
+	}
+
+	return b.Bytes()
+}
+
+func assTime(d time.Duration) string {
+	return fmt.Sprintf("%d:%02d:%02d.%02d",
+		int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60, d.Milliseconds()%1000/10)
+}
+
+// assText escapes braces, they start override tags in ASS
+func assText(s string) string {
+	return strings.NewReplacer(`{`, `\{`, `}`, `\}`, "\n", `\N`).Replace(s)
+}
@@ -0,0 +1,224 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed   = errors.New("malformed token")
+	ErrUnknownKey  = errors.New("unknown key")
+	ErrAlgorithm   = errors.New("unsupported algorithm")
+	ErrSignature   = errors.New("invalid signature")
+	ErrExpired     = errors.New("token expired")
+	ErrNotYetValid = errors.New("token not valid yet")
+	ErrIssuer      = errors.New("unexpected issuer")
+	ErrAudience    = errors.New("unexpected audience")
+)
+
+// KeySet maps key ids to verification keys: []byte HMAC secrets,
+// *rsa.PublicKey or ed25519.PublicKey. Tokens without a key id
+// are verified with the key stored under the empty id
+type KeySet map[string]any
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Claims are the registered claims the verifier checks
+// and the uid claim of the user the token was issued to
+type Claims struct {
+	UserID    int64    `json:"uid"`
+	Issuer    string   `json:"iss"`
+	Audience  Audience `json:"aud"`
+	ExpiresAt int64    `json:"exp"`
+	NotBefore int64    `json:"nbf"`
+}
+
+// Audience is the aud claim, a single string or an array of them
+type Audience []string
+
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*a = Audience{s}
+
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+
+	*a = list
+
+	return nil
+}
+
+// Options are checked by the verifier, empty Issuer and Audience aren't checked.
+// Leeway allows for clock skew between the issuer and the verifier
+type Options struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+type Verifier struct {
+	opts Options
+	now  func() time.Time
+}
+
+func NewVerifier(opts Options) *Verifier {
+	return &Verifier{
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// Verify checks the signature of the token with the key of its kid and
+// returns its claims. The token must have exp, the algorithm must match
+// the key type so a public key can't be used as an HMAC secret.
+// ErrUnknownKey is returned if keys have no key with the token kid
+func (v *Verifier) Verify(token string, keys KeySet) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decode(parts[0], &h); err != nil {
+		return nil, err
+	}
+
+	key, ok := keys[h.Kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, h.Kid)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err := decode(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err := v.check(&claims); err != nil {
+		return nil, err
+	}
+
+	return &claims, nil
+}
+
+func (v *Verifier) check(claims *Claims) error {
+	now := v.now()
+
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: no exp", ErrMalformed)
+	}
+
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(v.opts.Leeway)) {
+		return ErrExpired
+	}
+
+	if claims.NotBefore != 0 && now.Add(v.opts.Leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return ErrNotYetValid
+	}
+
+	if v.opts.Issuer != "" && claims.Issuer != v.opts.Issuer {
+		return fmt.Errorf("%w: %q", ErrIssuer, claims.Issuer)
+	}
+
+	if v.opts.Audience != "" && !slices.Contains(claims.Audience, v.opts.Audience) {
+		return ErrAudience
+	}
+
+	if claims.UserID <= 0 {
+		return fmt.Errorf("%w: no uid", ErrMalformed)
+	}
+
+	return nil
+}
+
+func verifySignature(alg string, key any, signed string, sig []byte) error {
+	var hash crypto.Hash
+
+	switch alg {
+	case "HS256", "RS256":
+		hash = crypto.SHA256
+	case "HS384", "RS384":
+		hash = crypto.SHA384
+	case "HS512", "RS512":
+		hash = crypto.SHA512
+	case "EdDSA":
+	default:
+		return fmt.Errorf("%w: %q", ErrAlgorithm, alg)
+	}
+
+	switch key := key.(type) {
+	case []byte:
+		if !strings.HasPrefix(alg, "HS") {
+			return fmt.Errorf("%w: %s with an HMAC secret", ErrAlgorithm, alg)
+		}
+
+		mac := hmac.New(hash.New, key)
+		mac.Write([]byte(signed))
+
+		if !hmac.Equal(sig, mac.Sum(nil)) {
+			return ErrSignature
+		}
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return fmt.Errorf("%w: %s with an RSA key", ErrAlgorithm, alg)
+		}
+
+		digest := hash.New()
+		digest.Write([]byte(signed))
+
+		if err := rsa.VerifyPKCS1v15(key, hash, digest.Sum(nil), sig); err != nil {
+			return ErrSignature
+		}
+	case ed25519.PublicKey:
+		if alg != "EdDSA" {
+			return fmt.Errorf("%w: %s with an Ed25519 key", ErrAlgorithm, alg)
+		}
+
+		if !ed25519.Verify(key, []byte(signed), sig) {
+			return ErrSignature
+		}
+	default:
+		return fmt.Errorf("%w: key type %T", ErrAlgorithm, key)
+	}
+
+	return nil
+}
+
+func decode(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return ErrMalformed
+	}
+
+	return nil
+}
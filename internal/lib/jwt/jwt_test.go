@@ -0,0 +1,230 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var now = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+type testKeys struct {
+	secret     []byte
+	rsaKey     *rsa.PrivateKey
+	ed25519Key ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return &testKeys{
+		secret:     []byte("secret"),
+		rsaKey:     rsaKey,
+		ed25519Key: edKey,
+	}
+}
+
+// sign issues a token with the header and claims signed by the key matching alg
+func (k *testKeys) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+
+	h, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	require.NoError(t, err)
+
+	c, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(c)
+
+	var sig []byte
+
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signed))
+		sig = mac.Sum(nil)
+	case "RS256":
+		digest := sha256.Sum256([]byte(signed))
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k.rsaKey, crypto.SHA256, digest[:])
+		require.NoError(t, err)
+	case "EdDSA":
+		sig = ed25519.Sign(k.ed25519Key, []byte(signed))
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func (k *testKeys) set() KeySet {
+	return KeySet{
+		"":    k.secret,
+		"rsa": &k.rsaKey.PublicKey,
+		"ed":  k.ed25519Key.Public().(ed25519.PublicKey),
+	}
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"uid": 42,
+		"iss": "sso",
+		"aud": "lyrics-library",
+		"exp": now.Add(time.Hour).Unix(),
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	claims[key] = value
+	return claims
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	v := NewVerifier(Options{Issuer: "sso", Audience: "lyrics-library", Leeway: 30 * time.Second})
+	v.now = func() time.Time { return now }
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedError error
+	}{
+		{
+			name:  "HMAC",
+			token: keys.sign(t, "HS256", "", validClaims()),
+		},
+		{
+			name:  "RSA",
+			token: keys.sign(t, "RS256", "rsa", validClaims()),
+		},
+		{
+			name:  "Ed25519",
+			token: keys.sign(t, "EdDSA", "ed", validClaims()),
+		},
+		{
+			name:  "audience list",
+			token: keys.sign(t, "EdDSA", "ed", with(validClaims(), "aud", []string{"other", "lyrics-library"})),
+		},
+		{
+			name:  "expired within leeway",
+			token: keys.sign(t, "HS256", "", with(validClaims(), "exp", now.Add(-10*time.Second).Unix())),
+		},
+		{
+			name:          "expired",
+			token:         keys.sign(t, "HS256", "", with(validClaims(), "exp", now.Add(-time.Minute).Unix())),
+			expectedError: ErrExpired,
+		},
+		{
+			name:          "without exp",
+			token:         keys.sign(t, "HS256", "", with(validClaims(), "exp", 0)),
+			expectedError: ErrMalformed,
+		},
+		{
+			name:          "not valid yet",
+			token:         keys.sign(t, "HS256", "", with(validClaims(), "nbf", now.Add(time.Minute).Unix())),
+			expectedError: ErrNotYetValid,
+		},
+		{
+			name:          "another issuer",
+			token:         keys.sign(t, "RS256", "rsa", with(validClaims(), "iss", "evil")),
+			expectedError: ErrIssuer,
+		},
+		{
+			name:          "another audience",
+			token:         keys.sign(t, "RS256", "rsa", with(validClaims(), "aud", "other")),
+			expectedError: ErrAudience,
+		},
+		{
+			name:          "unknown key id",
+			token:         keys.sign(t, "RS256", "rotated", validClaims()),
+			expectedError: ErrUnknownKey,
+		},
+		{
+			name:          "algorithm not matching the key",
+			token:         keys.sign(t, "HS256", "rsa", validClaims()),
+			expectedError: ErrAlgorithm,
+		},
+		{
+			name:          "none algorithm",
+			token:         keys.sign(t, "none", "", validClaims()),
+			expectedError: ErrAlgorithm,
+		},
+		{
+			name:          "signed by another key",
+			token:         newTestKeys(t).sign(t, "EdDSA", "ed", validClaims()),
+			expectedError: ErrSignature,
+		},
+		{
+			name:          "not a jwt",
+			token:         "token",
+			expectedError: ErrMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := v.Verify(tt.token, keys.set())
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, claims)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), claims.UserID)
+		})
+	}
+}
+
+func TestParseJWKS(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	data := fmt.Sprintf(`{"keys":[
+		{"kty":"RSA","kid":"rsa","use":"sig","n":%q,"e":%q},
+		{"kty":"OKP","kid":"ed","crv":"Ed25519","x":%q},
+		{"kty":"EC","kid":"ec","crv":"P-256","x":"AAAA","y":"AAAA"},
+		{"kty":"RSA","kid":"enc","use":"enc","n":"AQAB","e":"AQAB"}
+	]}`,
+		base64.RawURLEncoding.EncodeToString(keys.rsaKey.N.Bytes()),
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(keys.rsaKey.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(keys.ed25519Key.Public().(ed25519.PublicKey)),
+	)
+
+	set, err := ParseJWKS([]byte(data))
+	require.NoError(t, err)
+
+	assert.Len(t, set, 2)
+	assert.True(t, keys.rsaKey.PublicKey.Equal(set["rsa"]))
+	assert.Equal(t, keys.ed25519Key.Public(), set["ed"])
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"EC","kid":"ec"}]}`))
+	assert.ErrorIs(t, err, ErrNoKeys)
+
+	_, err = ParseJWKS([]byte(`{"keys":[{"kty":"OKP","crv":"Ed25519","x":"AAAA"}]}`))
+	assert.Error(t, err)
+}
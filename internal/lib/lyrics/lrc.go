@@ -0,0 +1,206 @@
+package lyrics
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lyrics-library/internal/domain/model"
+)
+
+var (
+	// lrcLine matches a line starting with one or more timestamps like [01:02.30]
+	lrcLine = regexp.MustCompile(`^((?:\[\d{1,3}:\d{1,2}(?:[.:]\d{1,3})?\]\s*)+)(.*)$`)
+	// lrcTimestamp captures minutes, seconds and the fraction of a timestamp
+	lrcTimestamp = regexp.MustCompile(`\[(\d{1,3}):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	// lrcTag matches id tags like [ar:Artist] or [offset:+500]
+	lrcTag = regexp.MustCompile(`^\[(ar|ti|al|au|by|length|offset|re|tool|ve|#):([^\]]*)\]$`)
+)
+
+// isLRC reports whether at least one line is a timed line with text,
+// a lone timestamp in plain lyrics is kept as a line
+func isLRC(lines []string) bool {
+	for _, line := range lines {
+		if m := lrcLine.FindStringSubmatch(line); m != nil && strings.TrimSpace(m[2]) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+type timedLine struct {
+	at   time.Duration
+	text string
+	// label is the section marker preceding the line, it starts a new stanza
+	label string
+	// stanzaStart is set for the first line after an empty line or a marker
+	stanzaStart bool
+}
+
+// parseLRC returns stanzas with a start time per line ordered by time.
+// A line with several timestamps is repeated at every one of them. Empty lines,
+// section markers and timestamps without text start a new stanza, untimed text is dropped
+func parseLRC(lines []string) []model.Stanza {
+	var (
+		timed       []timedLine
+		offset      time.Duration
+		label       string
+		stanzaStart bool
+	)
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+
+		if line == "" {
+			stanzaStart = true
+			continue
+		}
+
+		if m := lrcTag.FindStringSubmatch(line); m != nil {
+			// a positive offset shows lyrics earlier
+			if ms, err := strconv.Atoi(strings.TrimSpace(m[2])); m[1] == "offset" && err == nil {
+				offset = time.Duration(ms) * time.Millisecond
+			}
+			continue
+		}
+
+		if m := sectionMarker.FindStringSubmatch(line); m != nil {
+			label, stanzaStart = m[1], true
+			continue
+		}
+
+		m := lrcLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		text := strings.TrimSpace(m[2])
+
+		for _, ts := range lrcTimestamp.FindAllStringSubmatch(m[1], -1) {
+			timed = append(timed, timedLine{
+				at:          parseTimestamp(ts),
+				text:        text,
+				label:       label,
+				stanzaStart: stanzaStart,
+			})
+		}
+
+		label, stanzaStart = "", false
+	}
+
+	sort.SliceStable(timed, func(i, j int) bool {
+		return timed[i].at < timed[j].at
+	})
+
+	var (
+		stanzas []model.Stanza
+		current *model.Stanza
+		gap     bool
+	)
+
+	for _, line := range timed {
+		if line.text == "" {
+			gap = true
+			continue
+		}
+
+		if current == nil || gap || line.stanzaStart {
+			if current != nil {
+				stanzas = append(stanzas, *current)
+			}
+
+			current = &model.Stanza{Label: line.label}
+			gap = false
+		}
+
+		current.Lines = append(current.Lines, line.text)
+		current.Times = append(current.Times, max(line.at-offset, 0))
+	}
+
+	if current != nil {
+		stanzas = append(stanzas, *current)
+	}
+
+	return stanzas
+}
+
+// parseTimestamp converts a matched timestamp, the fraction may be
+// tenths, hundredths or milliseconds depending on its length
+func parseTimestamp(m []string) time.Duration {
+	minutes, _ := strconv.Atoi(m[1])
+	seconds, _ := strconv.Atoi(m[2])
+
+	d := time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+
+	if frac := m[3]; frac != "" {
+		n, _ := strconv.Atoi(frac)
+		for range 3 - len(frac) {
+			n *= 10
+		}
+
+		d += time.Duration(n) * time.Millisecond
+	}
+
+	return d
+}
+
+// Synced reports whether the stanzas have a start time for every line
+func Synced(stanzas []model.Stanza) bool {
+	if len(stanzas) == 0 {
+		return false
+	}
+
+	for _, stanza := range stanzas {
+		if len(stanza.Times) != len(stanza.Lines) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Position is a line of time-synced lyrics, End is the start of the next line
+// and zero for the last one
+type Position struct {
+	Stanza int
+	Line   int
+	// Index counts lines from the start of the lyrics
+	Index int
+	Start time.Duration
+	End   time.Duration
+}
+
+// LineAt returns the line sung at the playback position, false is returned
+// before the first line and for lyrics without timing
+func LineAt(stanzas []model.Stanza, at time.Duration) (Position, bool) {
+	if !Synced(stanzas) {
+		return Position{}, false
+	}
+
+	var (
+		found Position
+		ok    bool
+		index int
+	)
+
+	for i, stanza := range stanzas {
+		for j, start := range stanza.Times {
+			if start > at {
+				if ok {
+					found.End = start
+				}
+
+				return found, ok
+			}
+
+			found = Position{Stanza: i, Line: j, Index: index, Start: start}
+			ok = true
+			index++
+		}
+	}
+
+	return found, ok
+}
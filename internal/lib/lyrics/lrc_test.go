@@ -0,0 +1,88 @@
+package lyrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/domain/model"
+)
+
+func ms(n int) time.Duration {
+	return time.Duration(n) * time.Millisecond
+}
+
+func TestParseLRC(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"[ar:Juice WRLD]",
+		"[ti:Lucid Dreams]",
+		"[offset:+500]",
+		"[Chorus]",
+		"[00:01.50]I still see your shadows",
+		"[00:04.1][01:30.00]In my room",
+		"credits without timing",
+		"",
+		"[00:08:250]Can't take back the love",
+		"[00:10]",
+		"[00:12.000]That I gave you",
+	}
+
+	assert.Equal(t, []model.Stanza{
+		{Label: "Chorus", Lines: []string{"I still see your shadows", "In my room"}, Times: []time.Duration{ms(1000), ms(3600)}},
+		{Lines: []string{"Can't take back the love"}, Times: []time.Duration{ms(7750)}},
+		{Lines: []string{"That I gave you", "In my room"}, Times: []time.Duration{ms(11500), ms(89500)}},
+	}, Parse(lines))
+}
+
+func TestLineAt(t *testing.T) {
+	t.Parallel()
+
+	stanzas := []model.Stanza{
+		{Lines: []string{"one", "two"}, Times: []time.Duration{ms(1000), ms(3000)}},
+		{Lines: []string{"three"}, Times: []time.Duration{ms(6000)}},
+	}
+
+	tests := []struct {
+		name       string
+		at         time.Duration
+		expected   Position
+		expectedOK bool
+	}{
+		{name: "before the first line", at: ms(500)},
+		{
+			name:       "first line",
+			at:         ms(1000),
+			expected:   Position{Stanza: 0, Line: 0, Index: 0, Start: ms(1000), End: ms(3000)},
+			expectedOK: true,
+		},
+		{
+			name:       "between stanzas",
+			at:         ms(5999),
+			expected:   Position{Stanza: 0, Line: 1, Index: 1, Start: ms(3000), End: ms(6000)},
+			expectedOK: true,
+		},
+		{
+			name:       "last line",
+			at:         time.Minute,
+			expected:   Position{Stanza: 1, Line: 0, Index: 2, Start: ms(6000)},
+			expectedOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pos, ok := LineAt(stanzas, tt.at)
+
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, pos)
+		})
+	}
+
+	_, ok := LineAt([]model.Stanza{{Lines: []string{"one"}}}, time.Minute)
+	assert.False(t, ok)
+}
@@ -0,0 +1,125 @@
+package lyrics
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"lyrics-library/internal/domain/model"
+)
+
+// sectionMarker matches whole line markers like [Chorus] or [Verse 2: Artist],
+// lrc timestamps starting with a digit aren't markers
+var sectionMarker = regexp.MustCompile(`^\[([^\]\d][^\]]*)\]$`)
+
+// Parse splits lines into stanzas. An empty line or a section marker starts
+// a new stanza, the marker becomes the label of the stanza.
+// Lines in LRC format are parsed into time-synced stanzas
+func Parse(lines []string) []model.Stanza {
+	if isLRC(lines) {
+		return parseLRC(lines)
+	}
+
+	var (
+		stanzas []model.Stanza
+		current *model.Stanza
+	)
+
+	flush := func() {
+		if current != nil {
+			stanzas = append(stanzas, *current)
+			current = nil
+		}
+	}
+
+	for _, line := range lines {
+		if line == "" {
+			flush()
+			continue
+		}
+
+		if m := sectionMarker.FindStringSubmatch(line); m != nil {
+			flush()
+			current = &model.Stanza{Label: m[1], Lines: []string{}}
+			continue
+		}
+
+		if current == nil {
+			current = &model.Stanza{}
+		}
+
+		current.Lines = append(current.Lines, line)
+	}
+
+	flush()
+
+	return stanzas
+}
+
+// Flatten returns the stanzas as plain lines: stanzas are separated
+// by an empty line and labels are written back as [Label] markers
+func Flatten(stanzas []model.Stanza) []string {
+	if len(stanzas) == 0 {
+		return nil
+	}
+
+	var lines []string
+
+	for i, stanza := range stanzas {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		if stanza.Label != "" {
+			lines = append(lines, fmt.Sprintf("[%s]", stanza.Label))
+		}
+
+		lines = append(lines, stanza.Lines...)
+	}
+
+	return lines
+}
+
+// Lines returns only the lyric lines of the stanzas, separated by an empty line.
+// Labels are left out so they aren't translated
+func Lines(stanzas []model.Stanza) []string {
+	var lines []string
+
+	for i, stanza := range stanzas {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		lines = append(lines, stanza.Lines...)
+	}
+
+	return lines
+}
+
+// Align splits lines returned for Lines(shape) back into stanzas with the labels
+// and times of shape
+func Align(shape []model.Stanza, lines []string) ([]model.Stanza, error) {
+	if expected := len(Lines(shape)); len(lines) != expected {
+		return nil, fmt.Errorf("expected %d lines, got %d", expected, len(lines))
+	}
+
+	stanzas := make([]model.Stanza, len(shape))
+
+	pos := 0
+	for i, stanza := range shape {
+		if i > 0 {
+			pos++ // stanza separator
+		}
+
+		stanzas[i] = model.Stanza{
+			Label: stanza.Label,
+			Lines: append([]string{}, lines[pos:pos+len(stanza.Lines)]...),
+		}
+		if stanza.Times != nil {
+			stanzas[i].Times = append([]time.Duration{}, stanza.Times...)
+		}
+		pos += len(stanza.Lines)
+	}
+
+	return stanzas, nil
+}
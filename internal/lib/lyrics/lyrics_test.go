@@ -0,0 +1,67 @@
+package lyrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/domain/model"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{
+		"[Intro]",
+		"Yeah",
+		"",
+		"First verse line",
+		"Second verse line",
+		"[Chorus: Juice WRLD]",
+		"I still see your shadows",
+		"",
+		"[00:12.50]",
+	}
+
+	expected := []model.Stanza{
+		{Label: "Intro", Lines: []string{"Yeah"}},
+		{Lines: []string{"First verse line", "Second verse line"}},
+		{Label: "Chorus: Juice WRLD", Lines: []string{"I still see your shadows"}},
+		{Lines: []string{"[00:12.50]"}},
+	}
+
+	stanzas := Parse(lines)
+
+	assert.Equal(t, expected, stanzas)
+	assert.Equal(t, stanzas, Parse(Flatten(stanzas)))
+	assert.Nil(t, Parse(nil))
+	assert.Nil(t, Flatten(nil))
+}
+
+func TestAlign(t *testing.T) {
+	t.Parallel()
+
+	shape := []model.Stanza{
+		{Label: "Verse", Lines: []string{"one", "two"}},
+		{Label: "Chorus", Lines: []string{"three"}},
+	}
+
+	assert.Equal(t, []string{"one", "two", "", "three"}, Lines(shape))
+
+	aligned, err := Align(shape, []string{"раз", "два", "", "три"})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Stanza{
+		{Label: "Verse", Lines: []string{"раз", "два"}},
+		{Label: "Chorus", Lines: []string{"три"}},
+	}, aligned)
+
+	synced := []model.Stanza{{Lines: []string{"one"}, Times: []time.Duration{time.Second}}}
+
+	aligned, err = Align(synced, []string{"раз"})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.Stanza{{Lines: []string{"раз"}, Times: []time.Duration{time.Second}}}, aligned)
+
+	_, err = Align(shape, []string{"раз два", "три"})
+	assert.Error(t, err)
+}
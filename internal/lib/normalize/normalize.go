@@ -0,0 +1,46 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// featuring matches a "feat." suffix together with the featured artists
+var featuring = regexp.MustCompile(`[\s(\[]+(?:featuring|feat|ft)\b.*$`)
+
+// Name returns the canonical form of an artist or a title used to look tracks up:
+// lower case without diacritics, "feat." suffixes and punctuation, single spaced.
+// migrations/10_add_songs_name_keys.up.sql mirrors it to fill existing rows
+func Name(s string) string {
+	s = removeDiacritics(s)
+	s = strings.ToLower(s)
+	s = featuring.ReplaceAllString(s, "")
+
+	s = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			return r
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return -1
+	}, s)
+
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func removeDiacritics(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		return s
+	}
+
+	return result
+}
@@ -0,0 +1,36 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "case", input: "Juice WRLD", expected: "juice wrld"},
+		{name: "diacritics", input: "Beyoncé", expected: "beyonce"},
+		{name: "punctuation", input: "AC/DC", expected: "acdc"},
+		{name: "apostrophe and spaces", input: "  Guns N'   Roses ", expected: "guns n roses"},
+		{name: "feat suffix", input: "Lucid Dreams (feat. Lil Uzi Vert)", expected: "lucid dreams"},
+		{name: "ft suffix", input: "Wishing Well ft. Someone", expected: "wishing well"},
+		{name: "featuring suffix", input: "Song featuring Artist", expected: "song"},
+		{name: "ft inside word", input: "Daft Punk", expected: "daft punk"},
+		{name: "feat as the whole name", input: "Feat", expected: "feat"},
+		{name: "cyrillic", input: "Кино", expected: "кино"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, Name(tt.input))
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package playlist
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"lyrics-library/internal/domain/model"
+)
+
+var ErrInvalidPlaylist = errors.New("invalid playlist")
+
+type item struct {
+	Artist string `json:"artist"`
+	Title  string `json:"title"`
+	Lang   string `json:"lang"`
+}
+
+// ParseJSON parses an array of {"artist", "title", "lang"} objects, lang is optional
+func ParseJSON(r io.Reader) ([]model.BatchItem, error) {
+	var items []item
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPlaylist, err)
+	}
+
+	result := make([]model.BatchItem, 0, len(items))
+	for i, it := range items {
+		batchItem, err := toBatchItem(it)
+		if err != nil {
+			return nil, fmt.Errorf("%w: item %d: %v", ErrInvalidPlaylist, i+1, err)
+		}
+
+		result = append(result, batchItem)
+	}
+
+	return result, nil
+}
+
+// ParseCSV parses artist,title[,lang] rows. A header row naming the artist,
+// title and lang columns may set another column order
+func ParseCSV(r io.Reader) ([]model.BatchItem, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	columns := map[string]int{"artist": 0, "title": 1, "lang": 2}
+
+	var result []model.BatchItem
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPlaylist, err)
+		}
+
+		if row == 1 && isHeader(record) {
+			columns = headerColumns(record)
+			continue
+		}
+
+		batchItem, err := toBatchItem(item{
+			Artist: field(record, columns["artist"]),
+			Title:  field(record, columns["title"]),
+			Lang:   field(record, columns["lang"]),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidPlaylist, row, err)
+		}
+
+		result = append(result, batchItem)
+	}
+
+	return result, nil
+}
+
+func toBatchItem(it item) (model.BatchItem, error) {
+	batchItem := model.BatchItem{
+		Artist: strings.TrimSpace(it.Artist),
+		Title:  strings.TrimSpace(it.Title),
+		Lang:   strings.TrimSpace(it.Lang),
+	}
+
+	switch {
+	case batchItem.Artist == "":
+		return model.BatchItem{}, errors.New("artist is required")
+	case batchItem.Title == "":
+		return model.BatchItem{}, errors.New("title is required")
+	}
+
+	return batchItem, nil
+}
+
+func isHeader(record []string) bool {
+	for _, name := range record {
+		if strings.EqualFold(strings.TrimSpace(name), "artist") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func headerColumns(record []string) map[string]int {
+	columns := map[string]int{"artist": -1, "title": -1, "lang": -1}
+
+	for i, name := range record {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := columns[name]; ok {
+			columns[name] = i
+		}
+	}
+
+	return columns
+}
+
+// field returns the trimmed column i of the record, empty if it's missing
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,114 @@
+package playlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/domain/model"
+)
+
+func TestParseJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         string
+		expected      []model.BatchItem
+		expectedError string
+	}{
+		{
+			name:  "items",
+			input: `[{"artist":" Juice WRLD ","title":"Lucid Dreams"},{"artist":"Nirvana","title":"Lithium","lang":"en"}]`,
+			expected: []model.BatchItem{
+				{Artist: "Juice WRLD", Title: "Lucid Dreams"},
+				{Artist: "Nirvana", Title: "Lithium", Lang: "en"},
+			},
+		},
+		{
+			name:          "missing title",
+			input:         `[{"artist":"Nirvana","title":"Lithium"},{"artist":"Nirvana"}]`,
+			expectedError: "invalid playlist: item 2: title is required",
+		},
+		{
+			name:          "not an array",
+			input:         `{"artist":"Nirvana","title":"Lithium"}`,
+			expectedError: "invalid playlist: json: cannot unmarshal object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := ParseJSON(strings.NewReader(tt.input))
+
+			if tt.expectedError != "" {
+				assert.ErrorIs(t, err, ErrInvalidPlaylist)
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, items)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, items)
+		})
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         string
+		expected      []model.BatchItem
+		expectedError string
+	}{
+		{
+			name:  "rows without header",
+			input: "Juice WRLD,Lucid Dreams\nNirvana, Lithium, en\n",
+			expected: []model.BatchItem{
+				{Artist: "Juice WRLD", Title: "Lucid Dreams"},
+				{Artist: "Nirvana", Title: "Lithium", Lang: "en"},
+			},
+		},
+		{
+			name:  "header with another column order",
+			input: "Title,Artist,Album\nLucid Dreams,Juice WRLD,Goodbye & Good Riddance\n\"Back in Black\",\"AC/DC\",\n",
+			expected: []model.BatchItem{
+				{Artist: "Juice WRLD", Title: "Lucid Dreams"},
+				{Artist: "AC/DC", Title: "Back in Black"},
+			},
+		},
+		{
+			name:          "missing artist",
+			input:         "artist,title\nNirvana,Lithium\n,Polly\n",
+			expectedError: "invalid playlist: row 3: artist is required",
+		},
+		{
+			name:          "broken quotes",
+			input:         "Nirvana,\"Lithium\n",
+			expectedError: `extraneous or missing " in quoted-field`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			items, err := ParseCSV(strings.NewReader(tt.input))
+
+			if tt.expectedError != "" {
+				assert.ErrorIs(t, err, ErrInvalidPlaylist)
+				assert.ErrorContains(t, err, tt.expectedError)
+				assert.Nil(t, items)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, items)
+		})
+	}
+}
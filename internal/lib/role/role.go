@@ -0,0 +1,30 @@
+package role
+
+import (
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Key is the gin context key the auth middleware stores the caller's roles under
+const Key = "roles"
+
+// FromContext returns the caller's roles, false if they are unknown because
+// the request is anonymous or they failed to load
+func FromContext(c *gin.Context) ([]string, bool) {
+	v, ok := c.Get(Key)
+	if !ok {
+		return nil, false
+	}
+
+	roles, ok := v.([]string)
+
+	return roles, ok
+}
+
+// Has reports whether the caller has the role
+func Has(c *gin.Context, role string) bool {
+	roles, _ := FromContext(c)
+
+	return slices.Contains(roles, role)
+}
@@ -0,0 +1,18 @@
+package uid
+
+import "github.com/gin-gonic/gin"
+
+// Key is the gin context key the auth middleware stores the caller's user id under
+const Key = "uid"
+
+// FromContext returns the caller's user id, false if the request is anonymous
+func FromContext(c *gin.Context) (int64, bool) {
+	v, ok := c.Get(Key)
+	if !ok {
+		return 0, false
+	}
+
+	uid, ok := v.(int64)
+
+	return uid, ok
+}
@@ -0,0 +1,179 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/storage"
+)
+
+type Storage interface {
+	SaveAPIKey(ctx context.Context, key *model.APIKey, hash []byte) error
+	UserAPIKeys(ctx context.Context, userID int64) ([]*model.APIKey, error)
+	DeleteAPIKey(ctx context.Context, id string, userID int64) error
+	APIKeyOwner(ctx context.Context, hash []byte) (int64, error)
+	TouchAPIKey(ctx context.Context, hash []byte) error
+}
+
+var (
+	ErrKeyNotFound = errors.New("api key not found")
+	ErrKeyExists   = errors.New("api key already exists")
+	ErrInvalidKey  = errors.New("invalid api key")
+)
+
+const (
+	// Prefix starts every key, so keys are told apart from tokens
+	Prefix = "lk_"
+
+	// keyBytes is the random part of a key, 32 bytes make a 43 character key body
+	keyBytes = 32
+	// shownLen is the length of the key start kept readable to recognize the key by
+	shownLen = len(Prefix) + 8
+)
+
+// Service manages long-lived API keys of users. Only a SHA-256 hash of a key
+// is stored, a key holds 256 random bits so a plain hash can't be brute-forced
+type Service struct {
+	log     *slog.Logger
+	storage Storage
+}
+
+func New(log *slog.Logger, storage Storage) *Service {
+	return &Service{
+		log:     log,
+		storage: storage,
+	}
+}
+
+// IsKey reports whether the credential looks like an API key rather than a token
+func IsKey(credential string) bool {
+	return strings.HasPrefix(credential, Prefix)
+}
+
+// Create issues a new key named name to the user, the returned key is shown once
+// and can't be read again
+func (s *Service) Create(ctx context.Context, name string, userID int64) (*model.APIKey, string, error) {
+	const op = "service.apikey.Create"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	secret, err := newKey()
+	if err != nil {
+		log.Error("failed to generate api key", sl.Err(err))
+
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := &model.APIKey{
+		UserID: userID,
+		Name:   name,
+		Prefix: secret[:shownLen],
+	}
+
+	if err := s.storage.SaveAPIKey(ctx, key, hash(secret)); err != nil {
+		if errors.Is(err, storage.ErrAPIKeyExists) {
+			log.Warn("api key already exists")
+
+			return nil, "", fmt.Errorf("%s: %w", op, ErrKeyExists)
+		}
+
+		log.Error("failed to save api key", sl.Err(err))
+
+		return nil, "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("api key created", slog.String("id", key.ID))
+
+	return key, secret, nil
+}
+
+func (s *Service) List(ctx context.Context, userID int64) ([]*model.APIKey, error) {
+	const op = "service.apikey.List"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	keys, err := s.storage.UserAPIKeys(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user's api keys", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// Revoke deletes the user's key, requests with it are rejected right away
+func (s *Service) Revoke(ctx context.Context, id string, userID int64) error {
+	const op = "service.apikey.Revoke"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.storage.DeleteAPIKey(ctx, id, userID); err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			return fmt.Errorf("%s: %w", op, ErrKeyNotFound)
+		}
+
+		log.Error("failed to delete api key", sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("api key revoked", slog.String("id", id))
+
+	return nil
+}
+
+// Authenticate returns the owner of the key, ErrInvalidKey is returned
+// for unknown and revoked keys
+func (s *Service) Authenticate(ctx context.Context, key string) (int64, error) {
+	const op = "service.apikey.Authenticate"
+
+	log := s.log.With(slog.String("op", op))
+
+	if !IsKey(key) {
+		return 0, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+	}
+
+	sum := hash(key)
+
+	userID, err := s.storage.APIKeyOwner(ctx, sum)
+	if err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			return 0, fmt.Errorf("%s: %w", op, ErrInvalidKey)
+		}
+
+		log.Error("failed to look up api key", sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// last use time is informational, the request is served without it
+	if err := s.storage.TouchAPIKey(ctx, sum); err != nil {
+		log.Warn("failed to mark api key as used", sl.Err(err))
+	}
+
+	return userID, nil
+}
+
+func newKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return Prefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func hash(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+
+	return sum[:]
+}
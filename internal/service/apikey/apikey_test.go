@@ -0,0 +1,166 @@
+package apikey
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/service/apikey/mocks"
+	"lyrics-library/internal/storage"
+)
+
+const testKeyID = "9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59"
+
+type Mocks struct {
+	storage *mocks.Storage
+}
+
+func setupService(t *testing.T) (*Service, *Mocks) {
+	m := &Mocks{
+		storage: new(mocks.Storage),
+	}
+
+	t.Cleanup(func() {
+		m.storage.AssertExpectations(t)
+	})
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := New(log, m.storage)
+
+	return s, m
+}
+
+func TestService_Create(t *testing.T) {
+	t.Run("key created", func(t *testing.T) {
+		s, m := setupService(t)
+
+		var storedHash []byte
+
+		m.storage.On("SaveAPIKey", mock.Anything, mock.AnythingOfType("*model.APIKey"), mock.Anything).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*model.APIKey).ID = testKeyID
+				storedHash = args.Get(2).([]byte)
+			}).Return(nil)
+
+		key, secret, err := s.Create(context.Background(), "ci", 1)
+
+		assert.NoError(t, err)
+		assert.True(t, IsKey(secret))
+		assert.Len(t, secret, len(Prefix)+43)
+		assert.Equal(t, &model.APIKey{ID: testKeyID, UserID: 1, Name: "ci", Prefix: secret[:shownLen]}, key)
+
+		sum := sha256.Sum256([]byte(secret))
+		assert.Equal(t, sum[:], storedHash)
+	})
+
+	t.Run("name already used", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("SaveAPIKey", mock.Anything, mock.Anything, mock.Anything).
+			Return(fmt.Errorf("storage.postgres.SaveAPIKey: %w", storage.ErrAPIKeyExists))
+
+		key, secret, err := s.Create(context.Background(), "ci", 1)
+
+		assert.ErrorIs(t, err, ErrKeyExists)
+		assert.Nil(t, key)
+		assert.Empty(t, secret)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name          string
+		mockErr       error
+		expectedError error
+	}{
+		{
+			name: "key revoked",
+		},
+		{
+			name:          "key of another user",
+			mockErr:       fmt.Errorf("storage.postgres.DeleteAPIKey: %w", storage.ErrAPIKeyNotFound),
+			expectedError: ErrKeyNotFound,
+		},
+		{
+			name:          "storage error",
+			mockErr:       dbErr,
+			expectedError: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+
+			m.storage.On("DeleteAPIKey", mock.Anything, testKeyID, int64(1)).Return(tt.mockErr)
+
+			err := s.Revoke(context.Background(), testKeyID, 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	const key = "lk_q2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw"
+
+	sum := sha256.Sum256([]byte(key))
+
+	t.Run("known key", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("APIKeyOwner", mock.Anything, sum[:]).Return(int64(7), nil)
+		m.storage.On("TouchAPIKey", mock.Anything, sum[:]).Return(nil)
+
+		userID, err := s.Authenticate(context.Background(), key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), userID)
+	})
+
+	t.Run("failed to mark as used", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("APIKeyOwner", mock.Anything, sum[:]).Return(int64(7), nil)
+		m.storage.On("TouchAPIKey", mock.Anything, sum[:]).Return(errors.New("storage.postgres.TouchAPIKey: timeout"))
+
+		userID, err := s.Authenticate(context.Background(), key)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), userID)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("APIKeyOwner", mock.Anything, sum[:]).
+			Return(int64(0), fmt.Errorf("storage.postgres.APIKeyOwner: %w", storage.ErrAPIKeyNotFound))
+
+		_, err := s.Authenticate(context.Background(), key)
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("not a key", func(t *testing.T) {
+		s, _ := setupService(t)
+
+		_, err := s.Authenticate(context.Background(), "eyJhbGciOiJIUzI1NiJ9.e30.sig")
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
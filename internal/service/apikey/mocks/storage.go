@@ -0,0 +1,41 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+)
+
+type Storage struct {
+	mock.Mock
+}
+
+func (m *Storage) SaveAPIKey(ctx context.Context, key *model.APIKey, hash []byte) error {
+	args := m.Called(ctx, key, hash)
+	return args.Error(0)
+}
+
+func (m *Storage) UserAPIKeys(ctx context.Context, userID int64) ([]*model.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIKey), args.Error(1)
+}
+
+func (m *Storage) DeleteAPIKey(ctx context.Context, id string, userID int64) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func (m *Storage) APIKeyOwner(ctx context.Context, hash []byte) (int64, error) {
+	args := m.Called(ctx, hash)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Storage) TouchAPIKey(ctx context.Context, hash []byte) error {
+	args := m.Called(ctx, hash)
+	return args.Error(0)
+}
@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"golang.org/x/sync/errgroup"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackSaver interface {
+	Save(ctx context.Context, artist, title, lang string, userID int64) (*dto.TrackResponse, bool, error)
+}
+
+type JobSubmitter interface {
+	Submit(ctx context.Context, artist, title, lang string, userID int64) (*model.Job, error)
+}
+
+// Service imports lists of tracks, one failed track doesn't stop the rest
+type Service struct {
+	log          *slog.Logger
+	trackSaver   TrackSaver
+	jobSubmitter JobSubmitter
+	concurrency  int
+}
+
+// New creates the batch service, jobSubmitter is optional and may be nil
+// if items are never queued as background jobs
+func New(
+	log *slog.Logger,
+	trackSaver TrackSaver,
+	jobSubmitter JobSubmitter,
+	concurrency int,
+) *Service {
+	return &Service{
+		log:          log,
+		trackSaver:   trackSaver,
+		jobSubmitter: jobSubmitter,
+		concurrency:  max(concurrency, 1),
+	}
+}
+
+// Import saves the tracks at most concurrency at a time and returns
+// a result per item in the items order
+func (s *Service) Import(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult {
+	const op = "service.batch.Import"
+
+	log := s.log.With(slog.String("op", op), slog.Int("items", len(items)))
+
+	results := make([]model.BatchResult, len(items))
+
+	var g errgroup.Group
+	g.SetLimit(s.concurrency)
+
+	for i, item := range items {
+		g.Go(func() error {
+			results[i] = s.save(ctx, item, userID)
+			return nil
+		})
+	}
+
+	_ = g.Wait()
+
+	log.Info("batch imported")
+
+	return results
+}
+
+func (s *Service) save(ctx context.Context, item model.BatchItem, userID int64) model.BatchResult {
+	const op = "service.batch.save"
+
+	result := model.BatchResult{Item: item}
+
+	t, created, err := s.trackSaver.Save(ctx, item.Artist, item.Title, item.Lang, userID)
+	switch {
+	case err == nil && created:
+		result.Status = model.BatchSaved
+		result.TrackUUID = t.UUID
+	case err == nil:
+		result.Status = model.BatchExists
+		result.TrackUUID = t.UUID
+	case errors.Is(err, track.ErrLyricsNotFound):
+		result.Status = model.BatchNotFound
+	case errors.Is(err, track.ErrFailedTranslateLyrics):
+		result.Status = model.BatchTranslationFailed
+	default:
+		s.log.Error("failed to import track",
+			slog.String("op", op),
+			slog.String("artist", item.Artist),
+			slog.String("title", item.Title),
+			sl.Err(err),
+		)
+
+		result.Status = model.BatchFailed
+	}
+
+	return result
+}
+
+// Submit queues a background import job per item and returns a result
+// per item in the items order
+func (s *Service) Submit(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult {
+	const op = "service.batch.Submit"
+
+	log := s.log.With(slog.String("op", op), slog.Int("items", len(items)))
+
+	results := make([]model.BatchResult, len(items))
+
+	for i, item := range items {
+		results[i] = model.BatchResult{Item: item, Status: model.BatchFailed}
+
+		job, err := s.jobSubmitter.Submit(ctx, item.Artist, item.Title, item.Lang, userID)
+		if err != nil {
+			log.Error("failed to submit job", sl.Err(err))
+			continue
+		}
+
+		results[i].Status = model.BatchQueued
+		results[i].JobID = job.ID
+	}
+
+	log.Info("batch submitted")
+
+	return results
+}
@@ -0,0 +1,94 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/service/batch/mocks"
+	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type Mocks struct {
+	trackSaver   *mocks.TrackSaver
+	jobSubmitter *mocks.JobSubmitter
+}
+
+func setupService(t *testing.T) (*Service, *Mocks) {
+	m := &Mocks{
+		trackSaver:   new(mocks.TrackSaver),
+		jobSubmitter: new(mocks.JobSubmitter),
+	}
+
+	t.Cleanup(func() {
+		m.trackSaver.AssertExpectations(t)
+		m.jobSubmitter.AssertExpectations(t)
+	})
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := New(log, m.trackSaver, m.jobSubmitter, 2)
+
+	return s, m
+}
+
+func TestService_Import(t *testing.T) {
+	s, m := setupService(t)
+
+	items := []model.BatchItem{
+		{Artist: "Artist1", Title: "Song1"},
+		{Artist: "Artist2", Title: "Song2", Lang: "en"},
+		{Artist: "Artist3", Title: "Song3"},
+		{Artist: "Artist4", Title: "Song4"},
+		{Artist: "Artist5", Title: "Song5"},
+	}
+
+	m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "", int64(1)).
+		Return(&dto.TrackResponse{UUID: "uuid1"}, true, nil)
+	m.trackSaver.On("Save", mock.Anything, "Artist2", "Song2", "en", int64(1)).
+		Return(&dto.TrackResponse{UUID: "uuid2"}, false, nil)
+	m.trackSaver.On("Save", mock.Anything, "Artist3", "Song3", "", int64(1)).
+		Return(nil, false, fmt.Errorf("service.track.Save: %w", track.ErrLyricsNotFound))
+	m.trackSaver.On("Save", mock.Anything, "Artist4", "Song4", "", int64(1)).
+		Return(nil, false, fmt.Errorf("service.track.Save: %w", track.ErrFailedTranslateLyrics))
+	m.trackSaver.On("Save", mock.Anything, "Artist5", "Song5", "", int64(1)).
+		Return(nil, false, errors.New("db error"))
+
+	results := s.Import(context.Background(), items, 1)
+
+	assert.Equal(t, []model.BatchResult{
+		{Item: items[0], Status: model.BatchSaved, TrackUUID: "uuid1"},
+		{Item: items[1], Status: model.BatchExists, TrackUUID: "uuid2"},
+		{Item: items[2], Status: model.BatchNotFound},
+		{Item: items[3], Status: model.BatchTranslationFailed},
+		{Item: items[4], Status: model.BatchFailed},
+	}, results)
+}
+
+func TestService_Submit(t *testing.T) {
+	s, m := setupService(t)
+
+	items := []model.BatchItem{
+		{Artist: "Artist1", Title: "Song1"},
+		{Artist: "Artist2", Title: "Song2", Lang: "en"},
+	}
+
+	m.jobSubmitter.On("Submit", mock.Anything, "Artist1", "Song1", "", int64(1)).
+		Return(&model.Job{ID: "job1"}, nil)
+	m.jobSubmitter.On("Submit", mock.Anything, "Artist2", "Song2", "en", int64(1)).
+		Return(nil, errors.New("db error"))
+
+	results := s.Submit(context.Background(), items, 1)
+
+	assert.Equal(t, []model.BatchResult{
+		{Item: items[0], Status: model.BatchQueued, JobID: "job1"},
+		{Item: items[1], Status: model.BatchFailed},
+	}, results)
+}
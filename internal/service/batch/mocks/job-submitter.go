@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+)
+
+type JobSubmitter struct {
+	mock.Mock
+}
+
+func (m *JobSubmitter) Submit(ctx context.Context, artist, title, lang string, userID int64) (*model.Job, error) {
+	args := m.Called(ctx, artist, title, lang, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Job), args.Error(1)
+}
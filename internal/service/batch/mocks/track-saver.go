@@ -0,0 +1,21 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackSaver struct {
+	mock.Mock
+}
+
+func (m *TrackSaver) Save(ctx context.Context, artist, title, lang string, userID int64) (*dto.TrackResponse, bool, error) {
+	args := m.Called(ctx, artist, title, lang, userID)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*dto.TrackResponse), args.Bool(1), args.Error(2)
+}
@@ -0,0 +1,307 @@
+package collection
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/storage"
+)
+
+type Storage interface {
+	SaveCollection(ctx context.Context, collection *model.Collection) error
+	Collection(ctx context.Context, id string) (*model.Collection, error)
+	CollectionBySlug(ctx context.Context, slug string) (*model.Collection, error)
+	UserCollections(ctx context.Context, userID int64) ([]*model.Collection, error)
+	CollectionOwner(ctx context.Context, id string) (int64, error)
+	DeleteCollection(ctx context.Context, id string) error
+	AddCollectionTrack(ctx context.Context, id, trackUUID string) error
+	RemoveCollectionTrack(ctx context.Context, id, trackUUID string) error
+	ReorderCollectionTracks(ctx context.Context, id string, trackUUIDs []string) error
+	SetCollectionSlug(ctx context.Context, id, slug string) error
+}
+
+var (
+	ErrCollectionNotFound = errors.New("collection not found")
+	ErrCollectionExists   = errors.New("collection already exists")
+	ErrTrackNotFound      = errors.New("track not found")
+	ErrInvalidOrder       = errors.New("order must list every track of the collection once")
+)
+
+// slugBytes is the random part of a share slug, 12 bytes make a 16 character slug
+const slugBytes = 12
+
+// Service manages users' collections of tracks. Collections of other users
+// are reported as not found, so their ids can't be probed
+type Service struct {
+	log     *slog.Logger
+	storage Storage
+}
+
+func New(log *slog.Logger, storage Storage) *Service {
+	return &Service{
+		log:     log,
+		storage: storage,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, name string, userID int64) (*model.Collection, error) {
+	const op = "service.collection.Create"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	collection := &model.Collection{
+		UserID: userID,
+		Name:   name,
+	}
+
+	if err := s.storage.SaveCollection(ctx, collection); err != nil {
+		if errors.Is(err, storage.ErrCollectionExists) {
+			log.Warn("collection already exists")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrCollectionExists)
+		}
+
+		log.Error("failed to save collection", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("collection created", slog.String("id", collection.ID))
+
+	return collection, nil
+}
+
+// List returns the user's collections without their tracks
+func (s *Service) List(ctx context.Context, userID int64) ([]*model.Collection, error) {
+	const op = "service.collection.List"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	collections, err := s.storage.UserCollections(ctx, userID)
+	if err != nil {
+		log.Error("failed to get user's collections", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+// Collection returns the user's collection with its tracks in order
+func (s *Service) Collection(ctx context.Context, id string, userID int64) (*model.Collection, error) {
+	const op = "service.collection.Collection"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	collection, err := s.storage.Collection(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+		}
+
+		log.Error("failed to get collection", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if collection.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+	}
+
+	return collection, nil
+}
+
+// Shared returns the collection shared by the slug, anyone can read it
+func (s *Service) Shared(ctx context.Context, slug string) (*model.Collection, error) {
+	const op = "service.collection.Shared"
+
+	log := s.log.With(slog.String("op", op))
+
+	collection, err := s.storage.CollectionBySlug(ctx, slug)
+	if err != nil {
+		if errors.Is(err, storage.ErrCollectionNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+		}
+
+		log.Error("failed to get shared collection", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id string, userID int64) error {
+	const op = "service.collection.Delete"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.checkOwner(ctx, id, userID); err != nil {
+		return fail(log, op, "failed to read collection owner", err)
+	}
+
+	if err := s.storage.DeleteCollection(ctx, id); err != nil {
+		return fail(log, op, "failed to delete collection", err)
+	}
+
+	log.Info("collection deleted", slog.String("id", id))
+
+	return nil
+}
+
+// AddTrack appends the track to the end of the collection
+func (s *Service) AddTrack(ctx context.Context, id, trackUUID string, userID int64) error {
+	const op = "service.collection.AddTrack"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.checkOwner(ctx, id, userID); err != nil {
+		return fail(log, op, "failed to read collection owner", err)
+	}
+
+	if err := s.storage.AddCollectionTrack(ctx, id, trackUUID); err != nil {
+		return fail(log, op, "failed to add track to collection", err)
+	}
+
+	return nil
+}
+
+func (s *Service) RemoveTrack(ctx context.Context, id, trackUUID string, userID int64) error {
+	const op = "service.collection.RemoveTrack"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.checkOwner(ctx, id, userID); err != nil {
+		return fail(log, op, "failed to read collection owner", err)
+	}
+
+	if err := s.storage.RemoveCollectionTrack(ctx, id, trackUUID); err != nil {
+		return fail(log, op, "failed to remove track from collection", err)
+	}
+
+	return nil
+}
+
+// Reorder sets the order of the collection tracks and returns the reordered collection,
+// trackUUIDs must list every track of the collection once
+func (s *Service) Reorder(
+	ctx context.Context,
+	id string,
+	trackUUIDs []string,
+	userID int64,
+) (*model.Collection, error) {
+	const op = "service.collection.Reorder"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.checkOwner(ctx, id, userID); err != nil {
+		return nil, fail(log, op, "failed to read collection owner", err)
+	}
+
+	if err := s.storage.ReorderCollectionTracks(ctx, id, trackUUIDs); err != nil {
+		return nil, fail(log, op, "failed to reorder collection", err)
+	}
+
+	collection, err := s.storage.Collection(ctx, id)
+	if err != nil {
+		return nil, fail(log, op, "failed to get collection", err)
+	}
+
+	return collection, nil
+}
+
+// Share makes the collection readable by anyone with the returned slug,
+// a shared collection keeps its slug
+func (s *Service) Share(ctx context.Context, id string, userID int64) (string, error) {
+	const op = "service.collection.Share"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	collection, err := s.Collection(ctx, id, userID)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if collection.Slug != "" {
+		return collection.Slug, nil
+	}
+
+	slug, err := newSlug()
+	if err != nil {
+		log.Error("failed to generate slug", sl.Err(err))
+
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.storage.SetCollectionSlug(ctx, id, slug); err != nil {
+		return "", fail(log, op, "failed to share collection", err)
+	}
+
+	log.Info("collection shared", slog.String("id", id))
+
+	return slug, nil
+}
+
+// Unshare revokes the share slug of the collection
+func (s *Service) Unshare(ctx context.Context, id string, userID int64) error {
+	const op = "service.collection.Unshare"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	if err := s.checkOwner(ctx, id, userID); err != nil {
+		return fail(log, op, "failed to read collection owner", err)
+	}
+
+	if err := s.storage.SetCollectionSlug(ctx, id, ""); err != nil {
+		return fail(log, op, "failed to unshare collection", err)
+	}
+
+	log.Info("collection unshared", slog.String("id", id))
+
+	return nil
+}
+
+// checkOwner returns ErrCollectionNotFound if the collection belongs to another user
+func (s *Service) checkOwner(ctx context.Context, id string, userID int64) error {
+	ownerID, err := s.storage.CollectionOwner(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if ownerID != userID {
+		return ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+// fail maps storage errors to the service ones, unexpected errors are logged with msg
+func fail(log *slog.Logger, op, msg string, err error) error {
+	switch {
+	case errors.Is(err, ErrCollectionNotFound), errors.Is(err, storage.ErrCollectionNotFound):
+		return fmt.Errorf("%s: %w", op, ErrCollectionNotFound)
+	case errors.Is(err, storage.ErrTrackNotFound):
+		return fmt.Errorf("%s: %w", op, ErrTrackNotFound)
+	case errors.Is(err, storage.ErrInvalidOrder):
+		return fmt.Errorf("%s: %w", op, ErrInvalidOrder)
+	}
+
+	log.Error(msg, sl.Err(err))
+
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func newSlug() (string, error) {
+	b := make([]byte, slugBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
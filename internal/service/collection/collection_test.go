@@ -0,0 +1,309 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/service/collection/mocks"
+	"lyrics-library/internal/storage"
+)
+
+const (
+	testCollectionID = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+	testTrackUUID    = "e434dc13-ada5-4bde-b695-d97014dadebc"
+)
+
+type Mocks struct {
+	storage *mocks.Storage
+}
+
+func setupService(t *testing.T) (*Service, *Mocks) {
+	m := &Mocks{
+		storage: new(mocks.Storage),
+	}
+
+	t.Cleanup(func() {
+		m.storage.AssertExpectations(t)
+	})
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := New(log, m.storage)
+
+	return s, m
+}
+
+func TestService_Create(t *testing.T) {
+	tests := []struct {
+		name               string
+		mockSetup          func(*Mocks)
+		expectedCollection *model.Collection
+		expectedError      error
+	}{
+		{
+			name: "successful create",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SaveCollection", mock.Anything, &model.Collection{UserID: 1, Name: "Favorites"}).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*model.Collection).ID = testCollectionID
+					}).Return(nil)
+			},
+			expectedCollection: &model.Collection{ID: testCollectionID, UserID: 1, Name: "Favorites"},
+		},
+		{
+			name: "name already used",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SaveCollection", mock.Anything, mock.Anything).
+					Return(fmt.Errorf("storage.postgres.SaveCollection: %w", storage.ErrCollectionExists))
+			},
+			expectedError: ErrCollectionExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			collection, err := s.Create(context.Background(), "Favorites", 1)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, collection)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCollection, collection)
+		})
+	}
+}
+
+func TestService_Collection(t *testing.T) {
+	stored := &model.Collection{
+		ID:     testCollectionID,
+		UserID: 1,
+		Name:   "Favorites",
+		Tracks: []*model.Track{{UUID: testTrackUUID, Artist: "Juice WRLD", Title: "Lucid Dreams"}},
+	}
+
+	tests := []struct {
+		name               string
+		userID             int64
+		mockSetup          func(*Mocks)
+		expectedCollection *model.Collection
+		expectedError      error
+	}{
+		{
+			name:   "own collection",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Collection", mock.Anything, testCollectionID).Return(stored, nil)
+			},
+			expectedCollection: stored,
+		},
+		{
+			name:   "collection of another user",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Collection", mock.Anything, testCollectionID).Return(stored, nil)
+			},
+			expectedError: ErrCollectionNotFound,
+		},
+		{
+			name:   "collection not found",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Collection", mock.Anything, testCollectionID).
+					Return(nil, fmt.Errorf("storage.postgres.Collection: %w", storage.ErrCollectionNotFound))
+			},
+			expectedError: ErrCollectionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			collection, err := s.Collection(context.Background(), testCollectionID, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, collection)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCollection, collection)
+		})
+	}
+}
+
+func TestService_AddTrack(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	tests := []struct {
+		name          string
+		userID        int64
+		mockSetup     func(*Mocks)
+		expectedError error
+	}{
+		{
+			name:   "track added",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+				m.storage.On("AddCollectionTrack", mock.Anything, testCollectionID, testTrackUUID).Return(nil)
+			},
+		},
+		{
+			name:   "collection of another user",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+			},
+			expectedError: ErrCollectionNotFound,
+		},
+		{
+			name:   "track not stored",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+				m.storage.On("AddCollectionTrack", mock.Anything, testCollectionID, testTrackUUID).
+					Return(fmt.Errorf("storage.postgres.AddCollectionTrack: %w", storage.ErrTrackNotFound))
+			},
+			expectedError: ErrTrackNotFound,
+		},
+		{
+			name:   "storage error",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("CollectionOwner", mock.Anything, testCollectionID).
+					Return(int64(0), dbErr)
+			},
+			expectedError: dbErr,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			err := s.AddTrack(context.Background(), testCollectionID, testTrackUUID, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestService_Reorder(t *testing.T) {
+	order := []string{testTrackUUID, "7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"}
+
+	t.Run("reordered", func(t *testing.T) {
+		s, m := setupService(t)
+
+		reordered := &model.Collection{ID: testCollectionID, UserID: 1}
+
+		m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+		m.storage.On("ReorderCollectionTracks", mock.Anything, testCollectionID, order).Return(nil)
+		m.storage.On("Collection", mock.Anything, testCollectionID).Return(reordered, nil)
+
+		collection, err := s.Reorder(context.Background(), testCollectionID, order, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, reordered, collection)
+	})
+
+	t.Run("order misses a track", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+		m.storage.On("ReorderCollectionTracks", mock.Anything, testCollectionID, order).
+			Return(fmt.Errorf("storage.postgres.ReorderCollectionTracks: %w", storage.ErrInvalidOrder))
+
+		collection, err := s.Reorder(context.Background(), testCollectionID, order, 1)
+
+		assert.ErrorIs(t, err, ErrInvalidOrder)
+		assert.Nil(t, collection)
+	})
+}
+
+func TestService_Share(t *testing.T) {
+	t.Run("new slug", func(t *testing.T) {
+		s, m := setupService(t)
+
+		var stored string
+
+		m.storage.On("Collection", mock.Anything, testCollectionID).
+			Return(&model.Collection{ID: testCollectionID, UserID: 1}, nil)
+		m.storage.On("SetCollectionSlug", mock.Anything, testCollectionID, mock.AnythingOfType("string")).
+			Run(func(args mock.Arguments) {
+				stored = args.String(2)
+			}).Return(nil)
+
+		slug, err := s.Share(context.Background(), testCollectionID, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, slug, 16)
+		assert.Equal(t, stored, slug)
+	})
+
+	t.Run("already shared", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("Collection", mock.Anything, testCollectionID).
+			Return(&model.Collection{ID: testCollectionID, UserID: 1, Slug: "q2Vh0Zt1aLw3nXcR"}, nil)
+
+		slug, err := s.Share(context.Background(), testCollectionID, 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "q2Vh0Zt1aLw3nXcR", slug)
+	})
+
+	t.Run("collection of another user", func(t *testing.T) {
+		s, m := setupService(t)
+
+		m.storage.On("Collection", mock.Anything, testCollectionID).
+			Return(&model.Collection{ID: testCollectionID, UserID: 1}, nil)
+
+		slug, err := s.Share(context.Background(), testCollectionID, 2)
+
+		assert.ErrorIs(t, err, ErrCollectionNotFound)
+		assert.Empty(t, slug)
+	})
+}
+
+func TestService_Unshare(t *testing.T) {
+	s, m := setupService(t)
+
+	m.storage.On("CollectionOwner", mock.Anything, testCollectionID).Return(int64(1), nil)
+	m.storage.On("SetCollectionSlug", mock.Anything, testCollectionID, "").Return(nil)
+
+	assert.NoError(t, s.Unshare(context.Background(), testCollectionID, 1))
+}
+
+func TestService_Shared(t *testing.T) {
+	s, m := setupService(t)
+
+	m.storage.On("CollectionBySlug", mock.Anything, "unknown").
+		Return(nil, fmt.Errorf("storage.postgres.CollectionBySlug: %w", storage.ErrCollectionNotFound))
+
+	collection, err := s.Shared(context.Background(), "unknown")
+
+	assert.ErrorIs(t, err, ErrCollectionNotFound)
+	assert.Nil(t, collection)
+}
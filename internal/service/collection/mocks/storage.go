@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+)
+
+type Storage struct {
+	mock.Mock
+}
+
+func (m *Storage) SaveCollection(ctx context.Context, collection *model.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *Storage) Collection(ctx context.Context, id string) (*model.Collection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func (m *Storage) CollectionBySlug(ctx context.Context, slug string) (*model.Collection, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func (m *Storage) UserCollections(ctx context.Context, userID int64) ([]*model.Collection, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Collection), args.Error(1)
+}
+
+func (m *Storage) CollectionOwner(ctx context.Context, id string) (int64, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Storage) DeleteCollection(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *Storage) AddCollectionTrack(ctx context.Context, id, trackUUID string) error {
+	args := m.Called(ctx, id, trackUUID)
+	return args.Error(0)
+}
+
+func (m *Storage) RemoveCollectionTrack(ctx context.Context, id, trackUUID string) error {
+	args := m.Called(ctx, id, trackUUID)
+	return args.Error(0)
+}
+
+func (m *Storage) ReorderCollectionTracks(ctx context.Context, id string, trackUUIDs []string) error {
+	args := m.Called(ctx, id, trackUUIDs)
+	return args.Error(0)
+}
+
+func (m *Storage) SetCollectionSlug(ctx context.Context, id, slug string) error {
+	args := m.Called(ctx, id, slug)
+	return args.Error(0)
+}
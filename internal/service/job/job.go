@@ -0,0 +1,254 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/storage"
+	"lyrics-library/internal/transport/dto"
+)
+
+type Storage interface {
+	SaveJob(ctx context.Context, job *model.Job) error
+	Job(ctx context.Context, id string) (*model.Job, error)
+	ClaimJob(ctx context.Context) (*model.Job, error)
+	FinishJob(ctx context.Context, job *model.Job, trackUUID, errMsg string) error
+	RequeueStaleJobs(ctx context.Context, staleAfter time.Duration) (int64, error)
+}
+
+type TrackSaver interface {
+	Save(ctx context.Context, artist, title, lang string, userID int64) (*dto.TrackResponse, bool, error)
+}
+
+var ErrJobNotFound = errors.New("job not found")
+
+// Public job errors, the cause is only logged
+const (
+	errLyricsNotFound        = "track not found"
+	errFailedTranslateLyrics = "failed translate lyrics"
+	errInternal              = "internal error"
+)
+
+// Service runs track imports in background. Jobs are stored, so pending jobs
+// survive restarts and are shared by all app replicas
+type Service struct {
+	log        *slog.Logger
+	storage    Storage
+	trackSaver TrackSaver
+	cfg        config.JobsConfig
+	// wake tells an idle worker a job was submitted without waiting for the next poll
+	wake chan struct{}
+}
+
+func New(
+	log *slog.Logger,
+	storage Storage,
+	trackSaver TrackSaver,
+	cfg config.JobsConfig,
+) *Service {
+	return &Service{
+		log:        log,
+		storage:    storage,
+		trackSaver: trackSaver,
+		cfg:        cfg,
+		wake:       make(chan struct{}, 1),
+	}
+}
+
+// Submit stores a pending job importing the track, it's picked up by a worker
+func (s *Service) Submit(
+	ctx context.Context,
+	artist, title, lang string,
+	userID int64,
+) (*model.Job, error) {
+	const op = "service.job.Submit"
+
+	log := s.log.With(slog.String("op", op))
+
+	job := &model.Job{
+		UserID: userID,
+		Artist: artist,
+		Title:  title,
+		Lang:   lang,
+	}
+
+	if err := s.storage.SaveJob(ctx, job); err != nil {
+		log.Error("failed to save job", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	log.Info("job submitted", slog.String("id", job.ID))
+
+	return job, nil
+}
+
+// Job returns the job if it's anonymous or submitted by the user,
+// jobs of other users are reported as not found
+func (s *Service) Job(ctx context.Context, id string, userID int64) (*model.Job, error) {
+	const op = "service.job.Job"
+
+	log := s.log.With(slog.String("op", op))
+
+	job, err := s.storage.Job(ctx, id)
+	if err != nil {
+		if errors.Is(err, storage.ErrJobNotFound) {
+			return nil, fmt.Errorf("%s: %w", op, ErrJobNotFound)
+		}
+
+		log.Error("failed to get job", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if job.UserID != 0 && job.UserID != userID {
+		return nil, fmt.Errorf("%s: %w", op, ErrJobNotFound)
+	}
+
+	return job, nil
+}
+
+// Run starts the workers and blocks until ctx is done and the running jobs
+// are finished. Jobs left running by stopped replicas are run again
+func (s *Service) Run(ctx context.Context) {
+	const op = "service.job.Run"
+
+	log := s.log.With(slog.String("op", op))
+
+	s.requeueStale(ctx)
+
+	var wg sync.WaitGroup
+
+	for range max(s.cfg.Workers, 1) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+
+	log.Info("job workers started", slog.Int("workers", max(s.cfg.Workers, 1)))
+
+	requeue := time.NewTicker(s.cfg.StaleAfter)
+	defer requeue.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+
+			log.Info("job workers stopped")
+
+			return
+		case <-requeue.C:
+			s.requeueStale(ctx)
+		}
+	}
+}
+
+func (s *Service) requeueStale(ctx context.Context) {
+	const op = "service.job.requeueStale"
+
+	log := s.log.With(slog.String("op", op))
+
+	n, err := s.storage.RequeueStaleJobs(ctx, s.cfg.StaleAfter)
+	if err != nil {
+		log.Error("failed to requeue stale jobs", sl.Err(err))
+		return
+	}
+
+	if n > 0 {
+		log.Info("stale jobs requeued", slog.Int64("count", n))
+	}
+}
+
+// work runs pending jobs one by one and waits for new ones when there are none left
+func (s *Service) work(ctx context.Context) {
+	poll := time.NewTicker(s.cfg.PollInterval)
+	defer poll.Stop()
+
+	for {
+		for ctx.Err() == nil && s.runNext(ctx) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-poll.C:
+		}
+	}
+}
+
+// runNext claims and runs a pending job, false is returned if there was none.
+// A claimed job is finished even if ctx is done meanwhile
+func (s *Service) runNext(ctx context.Context) bool {
+	const op = "service.job.runNext"
+
+	log := s.log.With(slog.String("op", op))
+
+	job, err := s.storage.ClaimJob(ctx)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNoPendingJobs) && ctx.Err() == nil {
+			log.Error("failed to claim job", sl.Err(err))
+		}
+
+		return false
+	}
+
+	ctx = context.WithoutCancel(ctx)
+
+	log = log.With(slog.String("id", job.ID))
+
+	var trackUUID, errMsg string
+
+	t, _, err := s.trackSaver.Save(ctx, job.Artist, job.Title, job.Lang, job.UserID)
+	if err != nil {
+		log.Error("failed to import track", sl.Err(err))
+
+		errMsg = publicError(err)
+	} else {
+		trackUUID = t.UUID
+	}
+
+	if err := s.storage.FinishJob(ctx, job, trackUUID, errMsg); err != nil {
+		if errors.Is(err, storage.ErrJobNotRunning) {
+			log.Warn("job was requeued while running, its result is dropped",
+				slog.String("track_uuid", trackUUID), slog.String("error", errMsg))
+			return true
+		}
+
+		log.Error("failed to finish job", sl.Err(err))
+		return true
+	}
+
+	log.Info("job finished", slog.String("track_uuid", trackUUID), slog.String("error", errMsg))
+
+	return true
+}
+
+// publicError is the job error shown to the user, matching the errors of a synchronous import
+func publicError(err error) string {
+	switch {
+	case errors.Is(err, track.ErrLyricsNotFound):
+		return errLyricsNotFound
+	case errors.Is(err, track.ErrFailedTranslateLyrics):
+		return errFailedTranslateLyrics
+	default:
+		return errInternal
+	}
+}
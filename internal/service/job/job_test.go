@@ -0,0 +1,278 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/service/job/mocks"
+	"lyrics-library/internal/service/track"
+	"lyrics-library/internal/storage"
+	"lyrics-library/internal/transport/dto"
+)
+
+const testJobID = "7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"
+
+type Mocks struct {
+	storage    *mocks.Storage
+	trackSaver *mocks.TrackSaver
+}
+
+func setupService(t *testing.T) (*Service, *Mocks) {
+	m := &Mocks{
+		storage:    new(mocks.Storage),
+		trackSaver: new(mocks.TrackSaver),
+	}
+
+	t.Cleanup(func() {
+		m.storage.AssertExpectations(t)
+		m.trackSaver.AssertExpectations(t)
+	})
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	s := New(log, m.storage, m.trackSaver, config.JobsConfig{
+		Workers:      1,
+		PollInterval: time.Second,
+		StaleAfter:   time.Minute,
+	})
+
+	return s, m
+}
+
+func TestService_Submit(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockSetup     func(*Mocks)
+		expectedJob   *model.Job
+		expectedError error
+	}{
+		{
+			name: "successful submit",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SaveJob", mock.Anything, &model.Job{
+					UserID: 1,
+					Artist: "Artist1",
+					Title:  "Song1",
+					Lang:   "en",
+				}).Run(func(args mock.Arguments) {
+					job := args.Get(1).(*model.Job)
+					job.ID = testJobID
+					job.Status = model.JobPending
+				}).Return(nil)
+			},
+			expectedJob: &model.Job{
+				ID:     testJobID,
+				UserID: 1,
+				Artist: "Artist1",
+				Title:  "Song1",
+				Lang:   "en",
+				Status: model.JobPending,
+			},
+		},
+		{
+			name: "storage error",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SaveJob", mock.Anything, mock.Anything).
+					Return(errors.New("db error"))
+			},
+			expectedError: errors.New("service.job.Submit: db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			job, err := s.Submit(context.Background(), "Artist1", "Song1", "en", 1)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+				assert.Nil(t, job)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedJob, job)
+			assert.Len(t, s.wake, 1)
+		})
+	}
+}
+
+func TestService_Job(t *testing.T) {
+	tests := []struct {
+		name          string
+		userID        int64
+		mockSetup     func(*Mocks)
+		expectedJob   *model.Job
+		expectedError error
+	}{
+		{
+			name:   "own job",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Job", mock.Anything, testJobID).
+					Return(&model.Job{ID: testJobID, UserID: 1, Status: model.JobDone}, nil)
+			},
+			expectedJob: &model.Job{ID: testJobID, UserID: 1, Status: model.JobDone},
+		},
+		{
+			name:   "anonymous job",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Job", mock.Anything, testJobID).
+					Return(&model.Job{ID: testJobID, Status: model.JobPending}, nil)
+			},
+			expectedJob: &model.Job{ID: testJobID, Status: model.JobPending},
+		},
+		{
+			name:   "job of another user",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Job", mock.Anything, testJobID).
+					Return(&model.Job{ID: testJobID, UserID: 1, Status: model.JobDone}, nil)
+			},
+			expectedError: ErrJobNotFound,
+		},
+		{
+			name:   "job not found",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("Job", mock.Anything, testJobID).
+					Return(nil, fmt.Errorf("storage.postgres.Job: %w", storage.ErrJobNotFound))
+			},
+			expectedError: ErrJobNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			job, err := s.Job(context.Background(), testJobID, tt.userID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, job)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedJob, job)
+		})
+	}
+}
+
+func TestService_RunNext(t *testing.T) {
+	claimed := &model.Job{
+		ID:     testJobID,
+		UserID: 1,
+		Artist: "Artist1",
+		Title:  "Song1",
+		Lang:   "en",
+		Status: model.JobRunning,
+	}
+
+	tests := []struct {
+		name        string
+		mockSetup   func(*Mocks)
+		expectedRan bool
+	}{
+		{
+			name: "no pending jobs",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("ClaimJob", mock.Anything).
+					Return(nil, fmt.Errorf("storage.postgres.ClaimJob: %w", storage.ErrNoPendingJobs))
+			},
+		},
+		{
+			name: "track imported",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("ClaimJob", mock.Anything).Return(claimed, nil)
+				m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "en", int64(1)).
+					Return(&dto.TrackResponse{UUID: "uuid1"}, true, nil)
+				m.storage.On("FinishJob", mock.Anything, claimed, "uuid1", "").Return(nil)
+			},
+			expectedRan: true,
+		},
+		{
+			name: "lyrics not found",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("ClaimJob", mock.Anything).Return(claimed, nil)
+				m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "en", int64(1)).
+					Return(nil, false, fmt.Errorf("service.track.Save: %w", track.ErrLyricsNotFound))
+				m.storage.On("FinishJob", mock.Anything, claimed, "", "track not found").Return(nil)
+			},
+			expectedRan: true,
+		},
+		{
+			name: "internal error is hidden",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("ClaimJob", mock.Anything).Return(claimed, nil)
+				m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "en", int64(1)).
+					Return(nil, false, errors.New("db error"))
+				m.storage.On("FinishJob", mock.Anything, claimed, "", "internal error").Return(nil)
+			},
+			expectedRan: true,
+		},
+		{
+			name: "job requeued while running",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("ClaimJob", mock.Anything).Return(claimed, nil)
+				m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "en", int64(1)).
+					Return(&dto.TrackResponse{UUID: "uuid1"}, true, nil)
+				m.storage.On("FinishJob", mock.Anything, claimed, "uuid1", "").
+					Return(fmt.Errorf("storage.postgres.FinishJob: %w", storage.ErrJobNotRunning))
+			},
+			expectedRan: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			assert.Equal(t, tt.expectedRan, s.runNext(context.Background()))
+		})
+	}
+}
+
+func TestService_Run(t *testing.T) {
+	s, m := setupService(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	claimed := &model.Job{ID: testJobID, Artist: "Artist1", Title: "Song1"}
+
+	m.storage.On("RequeueStaleJobs", mock.Anything, time.Minute).Return(int64(1), nil).Once()
+	m.storage.On("ClaimJob", mock.Anything).Return(claimed, nil).Once()
+	m.trackSaver.On("Save", mock.Anything, "Artist1", "Song1", "", int64(0)).
+		Return(&dto.TrackResponse{UUID: "uuid1"}, true, nil).Once()
+	m.storage.On("FinishJob", mock.Anything, claimed, "uuid1", "").Return(nil).Once()
+	m.storage.On("ClaimJob", mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, storage.ErrNoPendingJobs)
+
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't stop after the context was canceled")
+	}
+}
@@ -0,0 +1,45 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+)
+
+type Storage struct {
+	mock.Mock
+}
+
+func (m *Storage) SaveJob(ctx context.Context, job *model.Job) error {
+	args := m.Called(ctx, job)
+	return args.Error(0)
+}
+
+func (m *Storage) Job(ctx context.Context, id string) (*model.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Job), args.Error(1)
+}
+
+func (m *Storage) ClaimJob(ctx context.Context) (*model.Job, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Job), args.Error(1)
+}
+
+func (m *Storage) FinishJob(ctx context.Context, job *model.Job, trackUUID, errMsg string) error {
+	args := m.Called(ctx, job, trackUUID, errMsg)
+	return args.Error(0)
+}
+
+func (m *Storage) RequeueStaleJobs(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	args := m.Called(ctx, staleAfter)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,74 @@
+package role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"lyrics-library/internal/lib/claims"
+	"lyrics-library/internal/lib/logger/sl"
+)
+
+// Role sources: table reads the user_roles table, sso reads the roles claim
+// of the token issued by the auth service
+const (
+	SourceTable = "table"
+	SourceSSO   = "sso"
+)
+
+var ErrUnknownSource = errors.New("unknown roles source")
+
+type Storage interface {
+	UserRoles(ctx context.Context, userID int64) ([]string, error)
+}
+
+type Service struct {
+	log     *slog.Logger
+	source  string
+	storage Storage
+}
+
+func New(log *slog.Logger, source string, storage Storage) (*Service, error) {
+	const op = "service.role.New"
+
+	if source != SourceTable && source != SourceSSO {
+		return nil, fmt.Errorf("%s: %w: %s", op, ErrUnknownSource, source)
+	}
+
+	return &Service{
+		log:     log,
+		source:  source,
+		storage: storage,
+	}, nil
+}
+
+// Roles returns roles of the user the validated token was issued to.
+// The token is empty for callers using an API key, they have no roles
+// when roles are read from the token
+func (s *Service) Roles(ctx context.Context, userID int64, token string) ([]string, error) {
+	const op = "service.role.Roles"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	var (
+		roles []string
+		err   error
+	)
+
+	switch {
+	case s.source == SourceSSO && token == "":
+		return nil, nil
+	case s.source == SourceSSO:
+		roles, err = claims.Roles(token)
+	default:
+		roles, err = s.storage.UserRoles(ctx, userID)
+	}
+	if err != nil {
+		log.Error("failed to get user's roles", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
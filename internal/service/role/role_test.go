@@ -0,0 +1,118 @@
+package role
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/claims"
+)
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) UserRoles(ctx context.Context, userID int64) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestNew(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	_, err := New(log, "ldap", new(MockStorage))
+
+	assert.ErrorIs(t, err, ErrUnknownSource)
+}
+
+func TestService_Roles(t *testing.T) {
+	dbErr := errors.New("db error")
+
+	adminToken := "eyJhbGciOiJIUzI1NiJ9." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"uid":1,"roles":["admin"]}`)) + ".c2lnbmF0dXJl"
+
+	tests := []struct {
+		name          string
+		source        string
+		token         string
+		mockSetup     func(*MockStorage)
+		expectedRoles []string
+		expectedError error
+	}{
+		{
+			name:   "roles from table",
+			source: SourceTable,
+			mockSetup: func(m *MockStorage) {
+				m.On("UserRoles", mock.Anything, int64(1)).Return([]string{"admin"}, nil)
+			},
+			expectedRoles: []string{"admin"},
+		},
+		{
+			name:   "user without roles",
+			source: SourceTable,
+			mockSetup: func(m *MockStorage) {
+				m.On("UserRoles", mock.Anything, int64(1)).Return(nil, nil)
+			},
+		},
+		{
+			name:   "storage error",
+			source: SourceTable,
+			mockSetup: func(m *MockStorage) {
+				m.On("UserRoles", mock.Anything, int64(1)).Return(nil, dbErr)
+			},
+			expectedError: dbErr,
+		},
+		{
+			name:          "roles from token",
+			source:        SourceSSO,
+			token:         adminToken,
+			mockSetup:     func(m *MockStorage) {},
+			expectedRoles: []string{"admin"},
+		},
+		{
+			name:      "api key caller with roles from token",
+			source:    SourceSSO,
+			mockSetup: func(m *MockStorage) {},
+		},
+		{
+			name:          "malformed token",
+			source:        SourceSSO,
+			token:         "token",
+			mockSetup:     func(m *MockStorage) {},
+			expectedError: claims.ErrMalformedToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStorage := new(MockStorage)
+			tt.mockSetup(mockStorage)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			s, err := New(log, tt.source, mockStorage)
+			assert.NoError(t, err)
+
+			roles, err := s.Roles(context.Background(), 1, tt.token)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, roles)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedRoles, roles)
+			}
+
+			mockStorage.AssertExpectations(t)
+		})
+	}
+}
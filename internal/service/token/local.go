@@ -0,0 +1,221 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/lib/jwt"
+	"lyrics-library/internal/lib/logger/sl"
+)
+
+// Verifier modes
+const (
+	ModeGRPC  = "grpc"
+	ModeLocal = "local"
+)
+
+const fetchTimeout = 5 * time.Second
+
+// NewParser returns the parser selected by cfg.Mode: the auth service client
+// itself or Local falling back to it for tokens signed by unknown keys
+func NewParser(ctx context.Context, log *slog.Logger, cfg config.AuthVerifierConfig, client Parser) (Parser, error) {
+	const op = "service.token.NewParser"
+
+	switch cfg.Mode {
+	case ModeGRPC:
+		return client, nil
+	case ModeLocal:
+		l, err := NewLocal(ctx, log, cfg, client)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return l, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown verifier mode %q", op, cfg.Mode)
+	}
+}
+
+// Local verifies tokens without calling the auth service. Keys are the
+// configured secret, used for tokens without a key id, and keys of a JWKS file
+// or URL. Tokens signed by a key missing from them are passed to the fallback
+type Local struct {
+	log      *slog.Logger
+	verifier *jwt.Verifier
+	fallback Parser
+	static   jwt.KeySet
+
+	client  *http.Client
+	jwksURL string
+	refresh time.Duration
+	now     func() time.Time
+
+	fetchMu   sync.Mutex
+	fetchedAt time.Time
+
+	mu      sync.RWMutex
+	fetched jwt.KeySet
+}
+
+func NewLocal(ctx context.Context, log *slog.Logger, cfg config.AuthVerifierConfig, fallback Parser) (*Local, error) {
+	const op = "service.token.NewLocal"
+
+	if cfg.Secret == "" && cfg.JWKSFile == "" && cfg.JWKSURL == "" {
+		return nil, fmt.Errorf("%s: no secret or key set configured", op)
+	}
+
+	static := jwt.KeySet{}
+
+	if cfg.JWKSFile != "" {
+		data, err := os.ReadFile(cfg.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		keys, err := jwt.ParseJWKS(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		maps.Copy(static, keys)
+	}
+
+	if cfg.Secret != "" {
+		static[""] = []byte(cfg.Secret)
+	}
+
+	l := &Local{
+		log: log,
+		verifier: jwt.NewVerifier(jwt.Options{
+			Issuer:   cfg.Issuer,
+			Audience: cfg.Audience,
+			Leeway:   cfg.Leeway,
+		}),
+		fallback: fallback,
+		static:   static,
+		client:   &http.Client{},
+		jwksURL:  cfg.JWKSURL,
+		refresh:  cfg.JWKSRefresh,
+		now:      time.Now,
+	}
+
+	if l.jwksURL != "" {
+		if err := l.fetch(ctx); err != nil {
+			log.Warn("failed to fetch key set, unknown keys are verified by the auth service",
+				slog.String("op", op), sl.Err(err))
+		}
+	}
+
+	return l, nil
+}
+
+// ParseToken returns the user the token was issued to. ErrInvalidToken is returned
+// for tokens failing verification, tokens signed by an unknown key are verified
+// by the fallback once keys of the JWKS URL are fetched again
+func (l *Local) ParseToken(ctx context.Context, token string) (int64, error) {
+	const op = "service.token.Local.ParseToken"
+
+	claims, err := l.verifier.Verify(token, l.keys())
+	if errors.Is(err, jwt.ErrUnknownKey) && l.refetch(ctx) {
+		claims, err = l.verifier.Verify(token, l.keys())
+	}
+
+	switch {
+	case errors.Is(err, jwt.ErrUnknownKey):
+		l.log.Debug("token signed by an unknown key, falling back to the auth service",
+			slog.String("op", op), sl.Err(err))
+
+		return l.fallback.ParseToken(ctx, token)
+	case err != nil:
+		return 0, fmt.Errorf("%s: %w: %w", op, ErrInvalidToken, err)
+	}
+
+	return claims.UserID, nil
+}
+
+func (l *Local) keys() jwt.KeySet {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.fetched) == 0 {
+		return l.static
+	}
+
+	keys := maps.Clone(l.fetched)
+	maps.Copy(keys, l.static)
+
+	return keys
+}
+
+// refetch fetches keys of the JWKS URL if they weren't fetched in the last
+// refresh interval and reports whether the keys were updated
+func (l *Local) refetch(ctx context.Context) bool {
+	const op = "service.token.Local.refetch"
+
+	if l.jwksURL == "" {
+		return false
+	}
+
+	l.fetchMu.Lock()
+	defer l.fetchMu.Unlock()
+
+	if l.now().Sub(l.fetchedAt) < l.refresh {
+		return false
+	}
+
+	if err := l.fetch(ctx); err != nil {
+		l.log.Warn("failed to fetch key set", slog.String("op", op), sl.Err(err))
+		return false
+	}
+
+	return true
+}
+
+func (l *Local) fetch(ctx context.Context) error {
+	const op = "service.token.Local.fetch"
+
+	l.fetchedAt = l.now()
+
+	ctx, cancel := context.WithTimeout(ctx, fetchTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.jwksURL, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status code %d", op, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	keys, err := jwt.ParseJWKS(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	l.mu.Lock()
+	l.fetched = keys
+	l.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,271 @@
+package token
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authClient "lyrics-library/internal/client/grpc/auth"
+	"lyrics-library/internal/client/grpc/auth/authtest"
+	"lyrics-library/internal/config"
+)
+
+const secret = "secret"
+
+func encode(s string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+func payload(exp time.Time) string {
+	return encode(fmt.Sprintf(`{"uid":7,"iss":"sso","exp":%d}`, exp.Unix()))
+}
+
+func hmacToken(exp time.Time) string {
+	signed := encode(`{"alg":"HS256","typ":"JWT"}`) + "." + payload(exp)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func ed25519Token(key ed25519.PrivateKey, kid string, exp time.Time) string {
+	signed := encode(fmt.Sprintf(`{"alg":"EdDSA","kid":%q}`, kid)) + "." + payload(exp)
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(ed25519.Sign(key, []byte(signed)))
+}
+
+func keySet(key ed25519.PrivateKey, kid string) string {
+	x := base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey))
+
+	return fmt.Sprintf(`{"keys":[{"kty":"OKP","crv":"Ed25519","kid":%q,"x":%q}]}`, kid, x)
+}
+
+// jwksServer serves the key set in keys and counts requests
+type jwksServer struct {
+	keys     atomic.Value
+	requests atomic.Int64
+}
+
+func startJWKS(t *testing.T, keys string) (*jwksServer, string) {
+	t.Helper()
+
+	s := &jwksServer{}
+	s.keys.Store(keys)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		s.requests.Add(1)
+		_, _ = io.WriteString(w, s.keys.Load().(string))
+	}))
+	t.Cleanup(srv.Close)
+
+	return s, srv.URL
+}
+
+func setupLocal(t *testing.T, srv *stubServer, cfg config.AuthVerifierConfig) *Local {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	client, err := authClient.New(log, &config.Config{
+		Auth: config.AuthConfig{Host: authtest.Host, Port: "0", Retries: 1},
+	}, authtest.Start(t, srv))
+	require.NoError(t, err)
+
+	cfg.Issuer = "sso"
+	cfg.Leeway = time.Second
+
+	l, err := NewLocal(context.Background(), log, cfg, client)
+	require.NoError(t, err)
+
+	return l
+}
+
+func generateKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	return key
+}
+
+func TestLocal_ParseToken(t *testing.T) {
+	key := generateKey(t)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, []byte(keySet(key, "file")), 0o600))
+
+	tests := []struct {
+		name          string
+		token         string
+		expectedUID   int64
+		expectedCalls int64
+		expectedError error
+	}{
+		{
+			name:        "secret",
+			token:       hmacToken(time.Now().Add(time.Hour)),
+			expectedUID: 7,
+		},
+		{
+			name:        "key of the key set file",
+			token:       ed25519Token(key, "file", time.Now().Add(time.Hour)),
+			expectedUID: 7,
+		},
+		{
+			name:          "expired",
+			token:         hmacToken(time.Now().Add(-time.Hour)),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "bad signature",
+			token:         ed25519Token(generateKey(t), "file", time.Now().Add(time.Hour)),
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "not a jwt",
+			token:         "opaque-token",
+			expectedError: ErrInvalidToken,
+		},
+		{
+			name:          "unknown key id",
+			token:         ed25519Token(generateKey(t), "rotated", time.Now().Add(time.Hour)),
+			expectedUID:   1,
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &stubServer{}
+			l := setupLocal(t, srv, config.AuthVerifierConfig{Secret: secret, JWKSFile: path})
+
+			userID, err := l.ParseToken(context.Background(), tt.token)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedUID, userID)
+			assert.Equal(t, tt.expectedCalls, srv.calls.Load())
+		})
+	}
+}
+
+func TestLocal_JWKSURL(t *testing.T) {
+	oldKey, newKey := generateKey(t), generateKey(t)
+
+	jwks, url := startJWKS(t, keySet(oldKey, "old"))
+
+	srv := &stubServer{}
+	l := setupLocal(t, srv, config.AuthVerifierConfig{JWKSURL: url, JWKSRefresh: time.Minute})
+
+	clock := time.Now()
+	l.now = func() time.Time { return clock }
+
+	ctx := context.Background()
+
+	userID, err := l.ParseToken(ctx, ed25519Token(oldKey, "old", time.Now().Add(time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+	assert.Equal(t, int64(1), jwks.requests.Load())
+
+	// the issuer rotated keys within the refresh interval, so the key set
+	// isn't fetched again and the token is passed to the auth service
+	jwks.keys.Store(keySet(newKey, "new"))
+	token := ed25519Token(newKey, "new", time.Now().Add(time.Hour))
+
+	userID, err = l.ParseToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+	assert.Equal(t, int64(1), jwks.requests.Load())
+	assert.Equal(t, int64(1), srv.calls.Load())
+
+	clock = clock.Add(time.Minute)
+
+	userID, err = l.ParseToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), userID)
+	assert.Equal(t, int64(2), jwks.requests.Load())
+	assert.Equal(t, int64(1), srv.calls.Load())
+}
+
+func TestNewParser(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	client := &stubParser{}
+
+	tests := []struct {
+		name        string
+		cfg         config.AuthVerifierConfig
+		expectLocal bool
+		expectError bool
+	}{
+		{
+			name: "grpc",
+			cfg:  config.AuthVerifierConfig{Mode: ModeGRPC},
+		},
+		{
+			name:        "local",
+			cfg:         config.AuthVerifierConfig{Mode: ModeLocal, Secret: secret},
+			expectLocal: true,
+		},
+		{
+			name:        "local without keys",
+			cfg:         config.AuthVerifierConfig{Mode: ModeLocal},
+			expectError: true,
+		},
+		{
+			name:        "local with missing key set file",
+			cfg:         config.AuthVerifierConfig{Mode: ModeLocal, JWKSFile: "missing.json"},
+			expectError: true,
+		},
+		{
+			name:        "unknown mode",
+			cfg:         config.AuthVerifierConfig{Mode: "jwt"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewParser(context.Background(), log, tt.cfg, client)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.expectLocal {
+				assert.IsType(t, &Local{}, parser)
+			} else {
+				assert.Same(t, client, parser)
+			}
+		})
+	}
+}
+
+type stubParser struct{}
+
+func (p *stubParser) ParseToken(_ context.Context, _ string) (int64, error) {
+	return 1, nil
+}
@@ -0,0 +1,126 @@
+package token
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	authClient "lyrics-library/internal/client/grpc/auth"
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/lib/claims"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/storage"
+)
+
+// Cache backends
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendNone   = "none"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// Parser verifies tokens, either by calling the auth service or locally, see NewParser
+type Parser interface {
+	ParseToken(ctx context.Context, token string) (int64, error)
+}
+
+type Cache interface {
+	SaveToken(ctx context.Context, hash string, userID int64, ttl time.Duration) error
+	Token(ctx context.Context, hash string) (int64, error)
+}
+
+// Service validates tokens with the parser and caches validated ones,
+// so repeated requests with the same token aren't verified again.
+// A revoked token stays accepted until its cache entry expires
+type Service struct {
+	log    *slog.Logger
+	parser Parser
+	cache  Cache
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// New returns the service caching tokens in cache, nil cache disables caching
+func New(log *slog.Logger, parser Parser, cache Cache, cfg config.AuthTokenCacheConfig) *Service {
+	return &Service{
+		log:    log,
+		parser: parser,
+		cache:  cache,
+		ttl:    cfg.TTL,
+		now:    time.Now,
+	}
+}
+
+// ParseToken returns the user the token was issued to, ErrInvalidToken is returned
+// for tokens rejected by the parser. Failed cache reads and writes fall back
+// to the parser
+func (s *Service) ParseToken(ctx context.Context, token string) (int64, error) {
+	const op = "service.token.ParseToken"
+
+	log := s.log.With(slog.String("op", op))
+
+	if s.cache == nil {
+		return s.parse(ctx, op, token)
+	}
+
+	hash := hashToken(token)
+
+	userID, err := s.cache.Token(ctx, hash)
+	if err == nil {
+		return userID, nil
+	}
+	if !errors.Is(err, storage.ErrTokenNotCached) {
+		log.Warn("failed to read cached token", sl.Err(err))
+	}
+
+	userID, err = s.parse(ctx, op, token)
+	if err != nil {
+		return 0, err
+	}
+
+	if ttl := s.cacheTTL(token); ttl > 0 {
+		if err := s.cache.SaveToken(ctx, hash, userID, ttl); err != nil {
+			log.Warn("failed to cache token", sl.Err(err))
+		}
+	}
+
+	return userID, nil
+}
+
+func (s *Service) parse(ctx context.Context, op, token string) (int64, error) {
+	userID, err := s.parser.ParseToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, authClient.ErrInvalidToken) || errors.Is(err, ErrInvalidToken) {
+			return 0, fmt.Errorf("%s: %w", op, ErrInvalidToken)
+		}
+
+		s.log.Error("failed to validate token", slog.String("op", op), sl.Err(err))
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+// cacheTTL is the configured ttl cut to the time left until the token expires
+func (s *Service) cacheTTL(token string) time.Duration {
+	exp, err := claims.Expiry(token)
+	if err != nil || exp.IsZero() {
+		return s.ttl
+	}
+
+	return min(s.ttl, exp.Sub(s.now()))
+}
+
+// hashToken keys the cache, so tokens themselves aren't kept in it
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,177 @@
+package token
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ssov1 "github.com/fvckinginsxne/protos/gen/go/sso"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authClient "lyrics-library/internal/client/grpc/auth"
+	"lyrics-library/internal/client/grpc/auth/authtest"
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/storage"
+	"lyrics-library/internal/storage/memory"
+)
+
+var now = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// stubServer accepts tokens issued to user 1 and rejects "rejected" ones
+type stubServer struct {
+	ssov1.UnimplementedAuthServer
+
+	calls       atomic.Int64
+	unavailable bool
+}
+
+func (s *stubServer) ParseToken(_ context.Context, req *ssov1.ParseTokenRequest) (*ssov1.ParseTokenResponse, error) {
+	s.calls.Add(1)
+
+	switch {
+	case s.unavailable:
+		return nil, status.Error(codes.Unavailable, "connection refused")
+	case req.Token == "rejected":
+		return nil, status.Error(codes.InvalidArgument, "invalid token")
+	}
+
+	return &ssov1.ParseTokenResponse{Uid: 1}, nil
+}
+
+// recordingCache remembers the ttl tokens are cached for
+type recordingCache struct {
+	ttl time.Duration
+}
+
+func (c *recordingCache) SaveToken(_ context.Context, _ string, _ int64, ttl time.Duration) error {
+	c.ttl = ttl
+	return nil
+}
+
+func (c *recordingCache) Token(_ context.Context, _ string) (int64, error) {
+	return 0, storage.ErrTokenNotCached
+}
+
+func unsignedToken(exp time.Time) string {
+	payload := fmt.Sprintf(`{"uid":1,"exp":%d}`, exp.Unix())
+
+	return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".c2lnbmF0dXJl"
+}
+
+func setupService(t *testing.T, srv *stubServer, cache Cache) *Service {
+	t.Helper()
+
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	cfg := &config.Config{
+		Auth: config.AuthConfig{Host: authtest.Host, Port: "0", Retries: 1},
+	}
+
+	parser, err := authClient.New(log, cfg, authtest.Start(t, srv))
+	require.NoError(t, err)
+
+	s := New(log, parser, cache, config.AuthTokenCacheConfig{TTL: time.Minute})
+	s.now = func() time.Time { return now }
+
+	return s
+}
+
+func TestService_ParseToken(t *testing.T) {
+	t.Run("validated token is cached", func(t *testing.T) {
+		srv := &stubServer{}
+		s := setupService(t, srv, memory.New(10))
+
+		token := unsignedToken(now.Add(time.Hour))
+
+		for range 3 {
+			userID, err := s.ParseToken(context.Background(), token)
+
+			assert.NoError(t, err)
+			assert.Equal(t, int64(1), userID)
+		}
+
+		assert.Equal(t, int64(1), srv.calls.Load())
+	})
+
+	t.Run("rejected token isn't cached", func(t *testing.T) {
+		srv := &stubServer{}
+		s := setupService(t, srv, memory.New(10))
+
+		for range 2 {
+			_, err := s.ParseToken(context.Background(), "rejected")
+
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		}
+
+		assert.Equal(t, int64(2), srv.calls.Load())
+	})
+
+	t.Run("auth service unavailable", func(t *testing.T) {
+		srv := &stubServer{unavailable: true}
+		s := setupService(t, srv, memory.New(10))
+
+		userID, err := s.ParseToken(context.Background(), unsignedToken(now.Add(time.Hour)))
+
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidToken)
+		assert.Zero(t, userID)
+	})
+
+	t.Run("without cache", func(t *testing.T) {
+		srv := &stubServer{}
+		s := setupService(t, srv, nil)
+
+		token := unsignedToken(now.Add(time.Hour))
+
+		for range 2 {
+			_, err := s.ParseToken(context.Background(), token)
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, int64(2), srv.calls.Load())
+	})
+}
+
+func TestService_CacheTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		expectedTTL time.Duration
+	}{
+		{
+			name:        "token expiring after ttl",
+			token:       unsignedToken(now.Add(time.Hour)),
+			expectedTTL: time.Minute,
+		},
+		{
+			name:        "token expiring before ttl",
+			token:       unsignedToken(now.Add(20 * time.Second)),
+			expectedTTL: 20 * time.Second,
+		},
+		{
+			name:        "token without exp",
+			token:       "opaque-token",
+			expectedTTL: time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := &recordingCache{}
+			s := setupService(t, &stubServer{}, cache)
+
+			_, err := s.ParseToken(context.Background(), tt.token)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTTL, cache.ttl)
+		})
+	}
+}
@@ -6,23 +6,24 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/storage"
 )
 
 type Cache struct {
 	mock.Mock
 }
 
-func (m *Cache) SaveArtistTracks(ctx context.Context, artist string, tracks []*model.Track) error {
-	args := m.Called(ctx, artist, tracks)
+func (m *Cache) SaveArtistTracks(ctx context.Context, artist, page string, tracks *model.TrackPage) error {
+	args := m.Called(ctx, artist, page, tracks)
 	return args.Error(0)
 }
 
-func (m *Cache) ArtistTracks(ctx context.Context, artist string) ([]*model.Track, error) {
-	args := m.Called(ctx, artist)
+func (m *Cache) ArtistTracks(ctx context.Context, artist, page string) (*model.TrackPage, error) {
+	args := m.Called(ctx, artist, page)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*model.Track), args.Error(1)
+	return args.Get(0).(*model.TrackPage), args.Error(1)
 }
 
 func (m *Cache) Track(ctx context.Context, artist, title string) (*model.Track, error) {
@@ -37,3 +38,36 @@ func (m *Cache) SaveTrack(ctx context.Context, track *model.Track) error {
 	args := m.Called(ctx, track)
 	return args.Error(0)
 }
+
+func (m *Cache) TrackByUUID(ctx context.Context, uuid string) (*model.Track, error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Track), args.Error(1)
+}
+
+func (m *Cache) InvalidateTrack(ctx context.Context, track *model.Track) error {
+	args := m.Called(ctx, track)
+	return args.Error(0)
+}
+
+func (m *Cache) InvalidateArtistTracks(ctx context.Context, artist string) error {
+	args := m.Called(ctx, artist)
+	return args.Error(0)
+}
+
+func (m *Cache) SaveMiss(ctx context.Context, miss storage.Miss, artist, title string) error {
+	args := m.Called(ctx, miss, artist, title)
+	return args.Error(0)
+}
+
+func (m *Cache) Missed(ctx context.Context, miss storage.Miss, artist, title string) (bool, error) {
+	args := m.Called(ctx, miss, artist, title)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *Cache) InvalidateMisses(ctx context.Context, artist, title string) error {
+	args := m.Called(ctx, artist, title)
+	return args.Error(0)
+}
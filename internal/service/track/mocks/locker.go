@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type Locker struct {
+	mock.Mock
+}
+
+func (m *Locker) Lock(ctx context.Context, name string) (func(ctx context.Context) error, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(func(ctx context.Context) error), args.Error(1)
+}
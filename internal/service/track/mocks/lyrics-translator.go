@@ -10,8 +10,8 @@ type LyricsTranslator struct {
 	mock.Mock
 }
 
-func (m *LyricsTranslator) TranslateLyrics(ctx context.Context, lyrics []string) ([]string, error) {
-	args := m.Called(ctx, lyrics)
+func (m *LyricsTranslator) TranslateLyrics(ctx context.Context, lyrics []string, targetLang string) ([]string, error) {
+	args := m.Called(ctx, lyrics, targetLang)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
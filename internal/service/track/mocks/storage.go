@@ -17,6 +17,11 @@ func (m *Storage) SaveTrack(ctx context.Context, track *model.Track) error {
 	return args.Error(0)
 }
 
+func (m *Storage) SaveTranslation(ctx context.Context, uuid, lang string, stanzas []model.Stanza) error {
+	args := m.Called(ctx, uuid, lang, stanzas)
+	return args.Error(0)
+}
+
 func (m *Storage) Track(ctx context.Context, artist, title string) (*model.Track, error) {
 	args := m.Called(ctx, artist, title)
 	if args.Get(0) == nil {
@@ -25,15 +30,77 @@ func (m *Storage) Track(ctx context.Context, artist, title string) (*model.Track
 	return args.Get(0).(*model.Track), args.Error(1)
 }
 
-func (m *Storage) TracksByArtist(ctx context.Context, artist string) ([]*model.Track, error) {
-	args := m.Called(ctx, artist)
+func (m *Storage) TrackByUUID(ctx context.Context, uuid string) (*model.Track, error) {
+	args := m.Called(ctx, uuid)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Track), args.Error(1)
+}
+
+func (m *Storage) TracksByArtist(ctx context.Context, artist string, page model.PageQuery) (*model.TrackPage, error) {
+	args := m.Called(ctx, artist, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TrackPage), args.Error(1)
+}
+
+func (m *Storage) TracksByUser(ctx context.Context, userID int64) ([]*model.Track, error) {
+	args := m.Called(ctx, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*model.Track), args.Error(1)
 }
 
-func (m *Storage) DeleteTrack(ctx context.Context, uuid string) error {
-	args := m.Called(ctx, uuid)
+func (m *Storage) ExportTracks(ctx context.Context, filter model.ExportFilter, fn func(track *model.Track) error) error {
+	args := m.Called(ctx, filter, fn)
+	if export, ok := args.Get(0).(func(context.Context, model.ExportFilter, func(*model.Track) error) error); ok {
+		return export(ctx, filter, fn)
+	}
 	return args.Error(0)
 }
+
+func (m *Storage) SimilarTracks(ctx context.Context, artist, title string, limit int) ([]*model.Suggestion, error) {
+	args := m.Called(ctx, artist, title, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Suggestion), args.Error(1)
+}
+
+func (m *Storage) UpdateTrack(
+	ctx context.Context,
+	uuid string,
+	userID int64,
+	lyrics []model.Stanza,
+	lang string,
+	translation []model.Stanza,
+) (*model.Track, error) {
+	args := m.Called(ctx, uuid, userID, lyrics, lang, translation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Track), args.Error(1)
+}
+
+func (m *Storage) DeleteTrack(ctx context.Context, uuid string, userID int64, admin bool) (*model.Track, error) {
+	args := m.Called(ctx, uuid, userID, admin)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Track), args.Error(1)
+}
+
+func (m *Storage) SearchTracks(
+	ctx context.Context,
+	query, lang string,
+	limit int,
+) ([]*model.SearchResult, error) {
+	args := m.Called(ctx, query, lang, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.SearchResult), args.Error(1)
+}
@@ -5,34 +5,63 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/singleflight"
 
 	trackClient "lyrics-library/internal/client/http/track"
 	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/cursor"
 	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/lyrics"
+	"lyrics-library/internal/lib/normalize"
 	"lyrics-library/internal/storage"
 	"lyrics-library/internal/transport/dto"
 )
 
+// LyricsProvider returns the lyrics with the name of the source that served them
 type LyricsProvider interface {
-	Lyrics(ctx context.Context, artist, title string) ([]string, error)
+	Lyrics(ctx context.Context, artist, title string) (lyrics []string, provider string, err error)
 }
 
 type LyricsTranslator interface {
-	TranslateLyrics(ctx context.Context, lyrics []string) ([]string, error)
+	TranslateLyrics(ctx context.Context, lyrics []string, targetLang string) ([]string, error)
 }
 
 type Storage interface {
 	SaveTrack(ctx context.Context, track *model.Track) error
+	SaveTranslation(ctx context.Context, uuid, lang string, stanzas []model.Stanza) error
 	Track(ctx context.Context, artist, title string) (*model.Track, error)
-	TracksByArtist(ctx context.Context, artist string) ([]*model.Track, error)
-	DeleteTrack(ctx context.Context, uuid string) error
+	TrackByUUID(ctx context.Context, uuid string) (*model.Track, error)
+	TracksByArtist(ctx context.Context, artist string, page model.PageQuery) (*model.TrackPage, error)
+	TracksByUser(ctx context.Context, userID int64) ([]*model.Track, error)
+	ExportTracks(ctx context.Context, filter model.ExportFilter, fn func(track *model.Track) error) error
+	SearchTracks(ctx context.Context, query, lang string, limit int) ([]*model.SearchResult, error)
+	SimilarTracks(ctx context.Context, artist, title string, limit int) ([]*model.Suggestion, error)
+	UpdateTrack(
+		ctx context.Context,
+		uuid string,
+		userID int64,
+		lyrics []model.Stanza,
+		lang string,
+		translation []model.Stanza,
+	) (*model.Track, error)
+	DeleteTrack(ctx context.Context, uuid string, userID int64, admin bool) (*model.Track, error)
 }
 
 type Cache interface {
-	SaveArtistTracks(ctx context.Context, artist string, tracks []*model.Track) error
-	ArtistTracks(ctx context.Context, artist string) ([]*model.Track, error)
+	SaveArtistTracks(ctx context.Context, artist, page string, tracks *model.TrackPage) error
+	ArtistTracks(ctx context.Context, artist, page string) (*model.TrackPage, error)
 	Track(ctx context.Context, artist, title string) (*model.Track, error)
+	TrackByUUID(ctx context.Context, uuid string) (*model.Track, error)
 	SaveTrack(ctx context.Context, track *model.Track) error
+	InvalidateTrack(ctx context.Context, track *model.Track) error
+	InvalidateArtistTracks(ctx context.Context, artist string) error
+	SaveMiss(ctx context.Context, miss storage.Miss, artist, title string) error
+	Missed(ctx context.Context, miss storage.Miss, artist, title string) (bool, error)
+	InvalidateMisses(ctx context.Context, artist, title string) error
 }
 
 var (
@@ -41,22 +70,52 @@ var (
 	ErrTrackNotFound         = errors.New("track not found")
 	ErrArtistTracksNotFound  = errors.New("artist's tracks not found")
 	ErrInvalidUUID           = errors.New("invalid uuid")
+	ErrNotTrackOwner         = errors.New("track belongs to another user")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrInvalidPage           = errors.New("invalid page parameters")
+	ErrNotSynced             = errors.New("track has no synced lyrics")
+	ErrInvalidTranslation    = errors.New("translation lines don't match lyrics")
+	ErrInvalidLang           = errors.New("invalid language code")
+)
+
+// langCode matches language codes with an optional region or script
+// that fit the translations.lang column, like en, pt-br or zh-hans
+var langCode = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,6})?$`)
+
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+	maxSuggestions   = 5
 )
 
+// Locker is a lock shared by all app replicas, it's taken before a new track
+// is fetched and translated so replicas don't pay for the same track twice
+type Locker interface {
+	Lock(ctx context.Context, name string) (unlock func(ctx context.Context) error, err error)
+}
+
 type Service struct {
 	log              *slog.Logger
 	lyricsProvider   LyricsProvider
 	lyricsTranslator LyricsTranslator
 	storage          Storage
 	cache            Cache
+	locker           Locker
+	defaultLang      string
+	// flights coalesces concurrent identical requests within the replica
+	flights singleflight.Group
 }
 
+// New creates the track service, locker is optional and may be nil
+// for a single replica
 func New(
 	log *slog.Logger,
 	lyricsProvider LyricsProvider,
 	lyricsTranslator LyricsTranslator,
 	storage Storage,
 	cache Cache,
+	locker Locker,
+	defaultLang string,
 ) *Service {
 	return &Service{
 		log:              log,
@@ -64,138 +123,438 @@ func New(
 		lyricsTranslator: lyricsTranslator,
 		storage:          storage,
 		cache:            cache,
+		locker:           locker,
+		defaultLang:      defaultLang,
 	}
 }
 
+type saveResult struct {
+	track   *dto.TrackResponse
+	created bool
+	// userID is the caller the track was saved for
+	userID int64
+}
+
+// Save fetches, translates and stores the track. The returned flag is false
+// if the track was already stored and its lyrics weren't fetched again.
+// A stored track missing the lang translation is translated on demand.
+// Concurrent saves of the same track into the same lang share one result,
+// only the caller the new track was saved for gets the created flag
 func (s *Service) Save(
 	ctx context.Context,
-	artist, title string,
-) (*dto.TrackResponse, error) {
+	artist, title, lang string,
+	userID int64,
+) (*dto.TrackResponse, bool, error) {
 	const op = "service.track.Save"
 
-	log := s.log.With("op", op)
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := fmt.Sprintf("save:%s:%s:%s", lang, normalize.Name(artist), normalize.Name(title))
+
+	v, err, _ := s.flights.Do(key, func() (any, error) {
+		track, created, err := s.save(ctx, artist, title, lang, userID)
+		return saveResult{track: track, created: created, userID: userID}, err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	result := v.(saveResult)
+
+	return result.track, result.created && result.userID == userID, nil
+}
+
+func (s *Service) save(
+	ctx context.Context,
+	artist, title, lang string,
+	userID int64,
+) (*dto.TrackResponse, bool, error) {
+	const op = "service.track.Save"
+
+	log := s.log.With("op", op, slog.String("lang", lang))
 
 	log.Info("saving track")
 
-	cached, err := s.cache.Track(ctx, artist, title)
+	existing, cached, err := s.storedTrack(ctx, artist, title)
+	if errors.Is(err, storage.ErrTrackNotFound) {
+		unlock, locked := s.lock(ctx, log, artist, title)
+		defer unlock()
+
+		// another replica may have saved the track while the lock was taken
+		if locked {
+			existing, err = s.storage.Track(ctx, artist, title)
+		}
+	}
 	if err == nil {
-		log.Info("returning cached track")
+		log.Info("track already exists")
+
+		if err := s.ensureTranslation(ctx, log, existing, cached, lang); err != nil {
+			return nil, false, fmt.Errorf("%s: %w", op, err)
+		}
+
+		return dto.ToTrackResponse(existing, lang), false, nil
+	}
+	if !errors.Is(err, storage.ErrTrackNotFound) {
+		log.Error("failed to read track", sl.Err(err))
+
+		return nil, false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.missed(ctx, log, storage.MissLyrics, artist, title) {
+		log.Info("lyrics are cached as not found")
 
-		return dto.ToTrackResponse(cached), nil
+		return nil, false, fmt.Errorf("%s: %w", op, ErrLyricsNotFound)
 	}
 
-	lyrics, err := s.lyricsProvider.Lyrics(ctx, artist, title)
+	lines, provider, err := s.lyricsProvider.Lyrics(ctx, artist, title)
 	if err != nil {
 		if errors.Is(err, trackClient.ErrLyricsNotFound) {
 			log.Error("track not found", sl.Err(err))
 
-			return nil, fmt.Errorf("%s: %w", op, ErrLyricsNotFound)
+			s.saveMiss(ctx, log, storage.MissLyrics, artist, title)
+
+			return nil, false, fmt.Errorf("%s: %w", op, ErrLyricsNotFound)
 		}
 
 		log.Error("failed to fetch track", sl.Err(err))
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	log.Debug("track fetched", slog.Any("track", lyrics))
-
-	translation, err := s.lyricsTranslator.TranslateLyrics(ctx, lyrics)
-	if err != nil {
-		log.Error("failed translate track", sl.Err(err))
+	log.Debug("track fetched", slog.String("provider", provider), slog.Any("track", lines))
 
-		if errors.Is(err, trackClient.ErrFailedTranslateLyrics) {
+	stanzas := lyrics.Parse(lines)
 
-			return nil, fmt.Errorf("%s: %w", op, ErrFailedTranslateLyrics)
-		}
-
-		return nil, fmt.Errorf("%s: %w", op, err)
+	translation, err := s.translate(ctx, log, stanzas, lang)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", op, err)
 	}
 
 	track := &model.Track{
-		Artist:      artist,
-		Title:       title,
-		Lyrics:      lyrics,
-		Translation: translation,
+		UserID:       userID,
+		Artist:       artist,
+		Title:        title,
+		Lyrics:       stanzas,
+		Provider:     provider,
+		Translations: map[string][]model.Stanza{lang: translation},
 	}
 
 	if err := s.storage.SaveTrack(ctx, track); err != nil {
+		if errors.Is(err, storage.ErrTrackExists) {
+			log.Info("track was saved by a concurrent request")
+
+			existing, err := s.storage.Track(ctx, artist, title)
+			if err != nil {
+				log.Error("failed to read track", sl.Err(err))
+
+				return nil, false, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if err := s.ensureTranslation(ctx, log, existing, false, lang); err != nil {
+				return nil, false, fmt.Errorf("%s: %w", op, err)
+			}
+
+			return dto.ToTrackResponse(existing, lang), false, nil
+		}
+
 		log.Error("failed to create track", sl.Err(err))
 
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, false, fmt.Errorf("%s: %w", op, err)
 	}
 
-	go func() {
-		log.Info("saving track in cache")
+	// the new track belongs on cached pages of the artist's tracks
+	// and makes cached misses for it stale
+	if err := s.cache.InvalidateArtistTracks(ctx, track.Artist); err != nil {
+		log.Error("failed to invalidate cached artist tracks", sl.Err(err))
+	}
+	if err := s.cache.InvalidateMisses(ctx, track.Artist, track.Title); err != nil {
+		log.Error("failed to invalidate cached misses", sl.Err(err))
+	}
 
-		if err := s.cache.SaveTrack(ctx, track); err != nil {
-			log.Error("failed to cache track", sl.Err(err))
-		}
-	}()
+	s.cacheTrack(ctx, log, track)
 
 	log.Info("track saved successfully")
 
-	return dto.ToTrackResponse(track), nil
+	return dto.ToTrackResponse(track, lang), true, nil
 }
 
+// Track returns the stored track with its translation into lang,
+// concurrent reads of the same track share one result
 func (s *Service) Track(
 	ctx context.Context,
-	artist, title string,
+	artist, title, lang string,
 ) (*dto.TrackResponse, error) {
 	const op = "service.track.Track"
 
-	log := s.log.With(slog.String("op", op))
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := fmt.Sprintf("track:%s:%s:%s", lang, normalize.Name(artist), normalize.Name(title))
+
+	v, err, _ := s.flights.Do(key, func() (any, error) {
+		return s.findTrack(ctx, artist, title, lang)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*dto.TrackResponse), nil
+}
+
+func (s *Service) findTrack(
+	ctx context.Context,
+	artist, title, lang string,
+) (*dto.TrackResponse, error) {
+	const op = "service.track.Track"
+
+	log := s.log.With(slog.String("op", op), slog.String("lang", lang))
 
 	log.Info("getting track")
 
-	cached, err := s.cache.Track(ctx, artist, title)
-	if err == nil {
-		log.Info("returning cached track")
+	if s.missed(ctx, log, storage.MissTrack, artist, title) {
+		log.Info("track is cached as not found")
 
-		return dto.ToTrackResponse(cached), nil
+		return nil, fmt.Errorf("%s: %w", op, ErrTrackNotFound)
 	}
 
-	track, err := s.storage.Track(ctx, artist, title)
+	track, cached, err := s.storedTrack(ctx, artist, title)
 	if err != nil {
 		log.Error("failed to read track", sl.Err(err))
 
 		if errors.Is(err, storage.ErrTrackNotFound) {
+			s.saveMiss(ctx, log, storage.MissTrack, artist, title)
+
 			return nil, fmt.Errorf("%s: %w", op, ErrTrackNotFound)
 		}
 
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	go func() {
-		log.Info("caching track")
+	if err := s.ensureTranslation(ctx, log, track, cached, lang); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
-		if err := s.cache.SaveTrack(ctx, track); err != nil {
-			log.Error("failed to cache track", sl.Err(err))
-		}
-	}()
+	log.Info("track got successfully")
+
+	return dto.ToTrackResponse(track, lang), nil
+}
+
+// Suggestions returns stored tracks similar to the requested one, the best match first
+func (s *Service) Suggestions(ctx context.Context, artist, title string) ([]*dto.SuggestionResponse, error) {
+	const op = "service.track.Suggestions"
+
+	log := s.log.With(slog.String("op", op))
+
+	suggestions, err := s.storage.SimilarTracks(ctx, artist, title, maxSuggestions)
+	if err != nil {
+		log.Error("failed to find similar tracks", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("suggestions found", slog.Int("count", len(suggestions)))
+
+	return dto.ToSuggestionResponses(suggestions), nil
+}
+
+func (s *Service) TrackByUUID(ctx context.Context, uuid, lang string) (*dto.TrackResponse, error) {
+	const op = "service.track.TrackByUUID"
+
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("uuid", uuid),
+		slog.String("lang", lang),
+	)
+
+	track, cached, err := s.trackByUUID(ctx, log, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.ensureTranslation(ctx, log, track, cached, lang); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	log.Info("track got successfully")
 
-	return dto.ToTrackResponse(track), nil
+	return dto.ToTrackResponse(track, lang), nil
+}
+
+// LineAt returns the line of time-synced lyrics sung at the playback position
+// with its translation into lang. ErrNotSynced is returned for lyrics without timing
+func (s *Service) LineAt(
+	ctx context.Context,
+	uuid, lang string,
+	at time.Duration,
+) (*dto.TrackLineResponse, error) {
+	const op = "service.track.LineAt"
+
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("uuid", uuid),
+		slog.String("lang", lang),
+	)
+
+	track, cached, err := s.trackByUUID(ctx, log, uuid)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	// lyrics without timing aren't translated for a line that can't be found
+	if !lyrics.Synced(track.Lyrics) {
+		return nil, fmt.Errorf("%s: %w", op, ErrNotSynced)
+	}
+
+	if err := s.ensureTranslation(ctx, log, track, cached, lang); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	response := &dto.TrackLineResponse{
+		UUID:     track.UUID,
+		Lang:     lang,
+		Position: at.Seconds(),
+	}
+
+	pos, ok := lyrics.LineAt(track.Lyrics, at)
+	if !ok {
+		return response, nil
+	}
+
+	stanza := track.Lyrics[pos.Stanza]
+
+	response.Line = &dto.TimedLineResponse{
+		Index:    pos.Index,
+		Label:    stanza.Label,
+		Original: stanza.Lines[pos.Line],
+		Start:    pos.Start.Seconds(),
+		End:      pos.End.Seconds(),
+	}
+
+	if translation := track.Translations[lang]; pos.Stanza < len(translation) &&
+		pos.Line < len(translation[pos.Stanza].Lines) {
+		response.Line.Translation = translation[pos.Stanza].Lines[pos.Line]
+	}
+
+	return response, nil
+}
+
+// trackByUUID reads the track from cache or storage, the returned flag reports a cache hit
+func (s *Service) trackByUUID(
+	ctx context.Context,
+	log *slog.Logger,
+	uuid string,
+) (*model.Track, bool, error) {
+	log.Info("getting track by uuid")
+
+	track, err := s.cache.TrackByUUID(ctx, uuid)
+	cached := err == nil
+	if cached {
+		log.Info("found cached track")
+	} else {
+		track, err = s.storage.TrackByUUID(ctx, uuid)
+		if err != nil {
+			if errors.Is(err, storage.ErrTrackNotFound) {
+				log.Warn("track not found")
+
+				return nil, false, ErrTrackNotFound
+			}
+
+			log.Error("failed to read track", sl.Err(err))
+
+			return nil, false, err
+		}
+	}
+
+	return track, cached, nil
+}
+
+// ArtistTracks returns a page of artist's tracks with translations into lang,
+// tracks not translated into lang yet are returned without translation.
+// Concurrent reads of the same page share one result
+func (s *Service) ArtistTracks(
+	ctx context.Context,
+	artist, lang string,
+	req dto.PageRequest,
+) (*dto.TracksPageResponse, error) {
+	const op = "service.track.ArtistTracks"
+
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	key := fmt.Sprintf("artist_tracks:%s:%s:%d:%s:%s:%s",
+		lang, normalize.Name(artist), req.Limit, req.Sort, req.Fields, req.Cursor)
+
+	v, err, _ := s.flights.Do(key, func() (any, error) {
+		return s.findArtistTracks(ctx, artist, lang, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*dto.TracksPageResponse), nil
 }
 
-func (s *Service) ArtistTracks(ctx context.Context, artist string) ([]*dto.TrackResponse, error) {
+func (s *Service) findArtistTracks(
+	ctx context.Context,
+	artist, lang string,
+	req dto.PageRequest,
+) (*dto.TracksPageResponse, error) {
 	const op = "service.track.ArtistTracks"
 
 	log := s.log.With(slog.String("op", op))
 
-	cached, err := s.cache.ArtistTracks(ctx, artist)
+	query, err := pageQuery(req)
+	if err != nil {
+		log.Warn("invalid page request", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if s.missed(ctx, log, storage.MissArtistTracks, artist, "") {
+		log.Info("artist's tracks are cached as not found")
+
+		return nil, fmt.Errorf("%s: %w", op, ErrArtistTracksNotFound)
+	}
+
+	pageKey := artistTracksPageKey(query, req.Cursor)
+
+	cached, err := s.cache.ArtistTracks(ctx, artist, pageKey)
 	if err == nil {
 		log.Info("getting tracks from cache")
 
-		return dto.TracksToTrackResponses(cached), nil
+		return dto.ToTracksPageResponse(cached, lang), nil
 	}
 
-	tracks, err := s.storage.TracksByArtist(ctx, artist)
+	page, err := s.storage.TracksByArtist(ctx, artist, query)
 	if err != nil {
-		if errors.Is(err, storage.ErrArtistTracksNotFound) {
+		switch {
+		case errors.Is(err, storage.ErrArtistTracksNotFound):
 			log.Error("artist's track not found")
 
+			s.saveMiss(ctx, log, storage.MissArtistTracks, artist, "")
+
 			return nil, fmt.Errorf("%s: %w", op, ErrArtistTracksNotFound)
+		case errors.Is(err, storage.ErrInvalidCursor):
+			log.Warn("invalid cursor")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrInvalidCursor)
 		}
 
 		log.Error("failed to read tracks by artist", sl.Err(err))
@@ -206,28 +565,254 @@ func (s *Service) ArtistTracks(ctx context.Context, artist string) ([]*dto.Track
 	go func() {
 		log.Info("caching artist's tracks")
 
-		if err := s.cache.SaveArtistTracks(ctx, artist, tracks); err != nil {
+		if err := s.cache.SaveArtistTracks(ctx, artist, pageKey, page); err != nil {
 			log.Error("failed to cache artist tracks", sl.Err(err))
 		}
 	}()
 
-	log.Info("artist's tracks got successfully", slog.Any("tracks", tracks))
+	log.Info("artist's tracks got successfully", slog.Int("count", len(page.Tracks)))
 
-	return dto.TracksToTrackResponses(tracks), nil
+	return dto.ToTracksPageResponse(page, lang), nil
 }
 
-func (s *Service) Delete(ctx context.Context, uuid string) error {
+// pageQuery validates the page request and fills in defaults
+func pageQuery(req dto.PageRequest) (model.PageQuery, error) {
+	query := model.PageQuery{
+		Limit:   req.Limit,
+		SortBy:  strings.TrimPrefix(req.Sort, "-"),
+		Desc:    strings.HasPrefix(req.Sort, "-"),
+		Summary: req.Fields == dto.FieldsSummary,
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultPageLimit
+	}
+	if query.Limit > maxPageLimit {
+		return query, ErrInvalidPage
+	}
+
+	switch query.SortBy {
+	case "":
+		query.SortBy = model.SortByTitle
+	case model.SortByTitle, model.SortByCreatedAt:
+	default:
+		return query, ErrInvalidPage
+	}
+
+	after, err := cursor.Decode(req.Cursor)
+	if err != nil {
+		return query, ErrInvalidCursor
+	}
+
+	// a cursor is only valid for the order it was issued for
+	if after != nil && (after.SortBy != query.SortBy || after.Desc != query.Desc) {
+		return query, ErrInvalidCursor
+	}
+	query.After = after
+
+	return query, nil
+}
+
+// artistTracksPageKey identifies a page of artist's tracks in cache
+func artistTracksPageKey(query model.PageQuery, rawCursor string) string {
+	return fmt.Sprintf("%s:%t:%d:%t:%s", query.SortBy, query.Desc, query.Limit, query.Summary, rawCursor)
+}
+
+func (s *Service) UserTracks(ctx context.Context, userID int64) ([]*dto.TrackResponse, error) {
+	const op = "service.track.UserTracks"
+
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
+
+	log.Info("getting user's tracks")
+
+	tracks, err := s.storage.TracksByUser(ctx, userID)
+	if err != nil {
+		log.Error("failed to read tracks by user", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("user's tracks got successfully")
+
+	return dto.TracksToTrackResponses(tracks, s.defaultLang), nil
+}
+
+// Export calls fn for every stored track matching the filter, the tracks
+// are streamed from storage and never cached
+func (s *Service) Export(
+	ctx context.Context,
+	filter model.ExportFilter,
+	fn func(track *model.Track) error,
+) error {
+	const op = "service.track.Export"
+
+	log := s.log.With(slog.String("op", op),
+		slog.String("artist", filter.Artist),
+		slog.Int64("uid", filter.UserID),
+	)
+
+	log.Info("exporting tracks")
+
+	var count int
+
+	err := s.storage.ExportTracks(ctx, filter, func(track *model.Track) error {
+		count++
+		return fn(track)
+	})
+	if err != nil {
+		log.Error("failed to export tracks", slog.Int("exported", count), sl.Err(err))
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("tracks exported", slog.Int("exported", count))
+
+	return nil
+}
+
+// Search finds tracks by a phrase in their lyrics or translation into lang
+func (s *Service) Search(
+	ctx context.Context,
+	query, lang string,
+	limit int,
+) ([]*dto.SearchResultResponse, error) {
+	const op = "service.track.Search"
+
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("query", query),
+		slog.String("lang", lang),
+	)
+
+	log.Info("searching tracks")
+
+	results, err := s.storage.SearchTracks(ctx, query, lang, limit)
+	if err != nil {
+		log.Error("failed to search tracks", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log.Info("tracks found", slog.Int("count", len(results)))
+
+	return dto.ToSearchResultResponses(results, lang), nil
+}
+
+// Update replaces lyrics and the translation into lang, both are given as plain
+// lines and split into stanzas by empty lines and section markers. The translation
+// must have a line for every line of the lyrics, it takes their stanzas, labels and
+// times. New lyrics drop translations into other languages, they're translated again
+// on the next read. Only the owner updates the track, tracks without one aren't updated
+func (s *Service) Update(
+	ctx context.Context,
+	uuid string,
+	userID int64,
+	lines []string,
+	lang string,
+	translation []string,
+) (*dto.TrackResponse, error) {
+	const op = "service.track.Update"
+
+	lang, err := s.lang(lang)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	log := s.log.With(
+		slog.String("op", op),
+		slog.String("uuid", uuid),
+		slog.Int64("uid", userID),
+		slog.String("lang", lang),
+	)
+
+	log.Info("updating track")
+
+	stanzas := lyrics.Parse(lines)
+
+	var translated []model.Stanza
+	if translation != nil {
+		shape := stanzas
+		if shape == nil {
+			stored, err := s.storage.TrackByUUID(ctx, uuid)
+			if err != nil {
+				if errors.Is(err, storage.ErrTrackNotFound) {
+					log.Warn("track not found")
+
+					return nil, fmt.Errorf("%s: %w", op, ErrTrackNotFound)
+				}
+
+				log.Error("failed to read track", sl.Err(err))
+
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			shape = stored.Lyrics
+		}
+
+		aligned, err := lyrics.Align(shape, lyrics.Lines(lyrics.Parse(translation)))
+		if err != nil {
+			log.Warn("translation isn't aligned with lyrics", sl.Err(err))
+
+			return nil, fmt.Errorf("%s: %w: %w", op, ErrInvalidTranslation, err)
+		}
+
+		translated = aligned
+	}
+
+	track, err := s.storage.UpdateTrack(ctx, uuid, userID, stanzas, lang, translated)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrTrackNotFound):
+			log.Warn("track not found")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrTrackNotFound)
+		case errors.Is(err, storage.ErrNotTrackOwner):
+			log.Warn("attempt to update another user's track")
+
+			return nil, fmt.Errorf("%s: %w", op, ErrNotTrackOwner)
+		}
+
+		log.Error("failed to update track", sl.Err(err))
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.invalidateTrack(ctx, log, track)
+
+	log.Info("track updated successfully")
+
+	return dto.ToTrackResponse(track, lang), nil
+}
+
+// Delete removes the track saved by the user, admin may remove any track
+// including ones without an owner
+func (s *Service) Delete(ctx context.Context, uuid string, userID int64, admin bool) error {
 	const op = "service.track.Delete"
 
-	log := s.log.With(slog.String("op", op))
+	log := s.log.With(slog.String("op", op), slog.Int64("uid", userID))
 
 	log.Info("deleting track by uuid")
 
-	if err := s.storage.DeleteTrack(ctx, uuid); err != nil {
-		if errors.Is(err, storage.ErrInvalidUUID) {
+	if admin {
+		log.Info("track deleted by admin, ownership isn't checked")
+	}
+
+	track, err := s.storage.DeleteTrack(ctx, uuid, userID, admin)
+	if err != nil {
+		switch {
+		case errors.Is(err, storage.ErrInvalidUUID):
 			log.Error("invalid uuid")
 
 			return fmt.Errorf("%s: %w", op, ErrInvalidUUID)
+		case errors.Is(err, storage.ErrNotTrackOwner):
+			log.Warn("attempt to delete another user's track")
+
+			return fmt.Errorf("%s: %w", op, ErrNotTrackOwner)
 		}
 
 		log.Error("failed to delete track", sl.Err(err))
@@ -235,7 +820,173 @@ func (s *Service) Delete(ctx context.Context, uuid string) error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	s.invalidateTrack(ctx, log, track)
+
 	log.Info("track deleted successfully")
 
 	return nil
 }
+
+// storedTrack looks the track up in cache first and falls back to storage,
+// the returned flag reports a cache hit
+func (s *Service) storedTrack(ctx context.Context, artist, title string) (*model.Track, bool, error) {
+	cached, err := s.cache.Track(ctx, artist, title)
+	if err == nil {
+		s.log.Debug("found cached track")
+
+		return cached, true, nil
+	}
+
+	track, err := s.storage.Track(ctx, artist, title)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return track, false, nil
+}
+
+// ensureTranslation translates and stores the track into lang unless it's already translated,
+// the track is (re)cached afterwards if it wasn't read from cache or got a new translation
+func (s *Service) ensureTranslation(
+	ctx context.Context,
+	log *slog.Logger,
+	track *model.Track,
+	cached bool,
+	lang string,
+) error {
+	if _, ok := track.Translations[lang]; ok {
+		if !cached {
+			s.cacheTrack(ctx, log, track)
+		}
+
+		return nil
+	}
+
+	log.Info("translating stored track into new language")
+
+	translation, err := s.translate(ctx, log, track.Lyrics, lang)
+	if err != nil {
+		return err
+	}
+
+	if err := s.storage.SaveTranslation(ctx, track.UUID, lang, translation); err != nil {
+		log.Error("failed to save translation", sl.Err(err))
+
+		return err
+	}
+
+	if track.Translations == nil {
+		track.Translations = make(map[string][]model.Stanza)
+	}
+	track.Translations[lang] = translation
+
+	s.invalidateTrack(ctx, log, track)
+	s.cacheTrack(ctx, log, track)
+
+	return nil
+}
+
+// translate translates the lines of stanzas, labels are kept as they are
+func (s *Service) translate(
+	ctx context.Context,
+	log *slog.Logger,
+	stanzas []model.Stanza,
+	lang string,
+) ([]model.Stanza, error) {
+	translation, err := s.lyricsTranslator.TranslateLyrics(ctx, lyrics.Lines(stanzas), lang)
+	if err != nil {
+		log.Error("failed translate track", sl.Err(err))
+
+		if errors.Is(err, trackClient.ErrFailedTranslateLyrics) {
+			return nil, ErrFailedTranslateLyrics
+		}
+
+		return nil, err
+	}
+
+	aligned, err := lyrics.Align(stanzas, translation)
+	if err != nil {
+		log.Error("translation isn't aligned with lyrics", sl.Err(err))
+
+		return nil, ErrFailedTranslateLyrics
+	}
+
+	return aligned, nil
+}
+
+// lang returns the normalized language code, the default one if lang is empty.
+// ErrInvalidLang is returned for malformed codes, so they never reach the translator
+func (s *Service) lang(lang string) (string, error) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return s.defaultLang, nil
+	}
+
+	if !langCode.MatchString(lang) {
+		return "", ErrInvalidLang
+	}
+
+	return lang, nil
+}
+
+// invalidateTrack evicts the changed track and the pages of its artist's tracks it's listed on,
+// a failed eviction is only logged since storage is already changed
+func (s *Service) invalidateTrack(ctx context.Context, log *slog.Logger, track *model.Track) {
+	if err := s.cache.InvalidateTrack(ctx, track); err != nil {
+		log.Error("failed to invalidate cached track", sl.Err(err))
+	}
+
+	if err := s.cache.InvalidateArtistTracks(ctx, track.Artist); err != nil {
+		log.Error("failed to invalidate cached artist tracks", sl.Err(err))
+	}
+}
+
+// lock takes the replicas' lock on the track, the returned flag reports
+// whether it's held. Without a locker or when the lock can't be taken
+// the save goes on unlocked, duplicated upstream calls are preferred to failing
+func (s *Service) lock(ctx context.Context, log *slog.Logger, artist, title string) (func(), bool) {
+	if s.locker == nil {
+		return func() {}, false
+	}
+
+	unlock, err := s.locker.Lock(ctx, fmt.Sprintf("save:%s:%s", normalize.Name(artist), normalize.Name(title)))
+	if err != nil {
+		log.Warn("failed to lock track, saving without lock", sl.Err(err))
+
+		return func() {}, false
+	}
+
+	return func() {
+		if err := unlock(ctx); err != nil {
+			log.Error("failed to unlock track", sl.Err(err))
+		}
+	}, true
+}
+
+// missed reports a cached miss, cache errors are logged and treated as no miss
+func (s *Service) missed(ctx context.Context, log *slog.Logger, miss storage.Miss, artist, title string) bool {
+	missed, err := s.cache.Missed(ctx, miss, artist, title)
+	if err != nil {
+		log.Error("failed to read cached miss", sl.Err(err))
+
+		return false
+	}
+
+	return missed
+}
+
+func (s *Service) saveMiss(ctx context.Context, log *slog.Logger, miss storage.Miss, artist, title string) {
+	if err := s.cache.SaveMiss(ctx, miss, artist, title); err != nil {
+		log.Error("failed to cache miss", sl.Err(err))
+	}
+}
+
+func (s *Service) cacheTrack(ctx context.Context, log *slog.Logger, track *model.Track) {
+	go func() {
+		log.Info("saving track in cache")
+
+		if err := s.cache.SaveTrack(ctx, track); err != nil {
+			log.Error("failed to cache track", sl.Err(err))
+		}
+	}()
+}
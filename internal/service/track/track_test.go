@@ -5,15 +5,25 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	trackClient "lyrics-library/internal/client/http/track"
 	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/cursor"
+	"lyrics-library/internal/lib/lyrics"
 	"lyrics-library/internal/service/track/mocks"
+	"lyrics-library/internal/storage"
+	"lyrics-library/internal/transport/dto"
 )
 
+const testLang = "ru"
+
 type Mocks struct {
 	lyricsProvider   *mocks.LyricsProvider
 	lyricsTranslator *mocks.LyricsTranslator
@@ -37,19 +47,22 @@ func setupService(t *testing.T) (*Service, *Mocks) {
 	})
 
 	log := slog.New(slog.NewTextHandler(io.Discard, nil))
-	s := New(log, m.lyricsProvider, m.lyricsTranslator, m.storage, m.cache)
+	s := New(log, m.lyricsProvider, m.lyricsTranslator, m.storage, m.cache, nil, testLang)
 
 	return s, m
 }
 
 func TestService_Save(t *testing.T) {
 	tests := []struct {
-		name          string
-		artist        string
-		title         string
-		mockSetup     func(*Mocks)
-		expectedTrack *model.Track
-		expectedError error
+		name            string
+		artist          string
+		title           string
+		lang            string
+		mockSetup       func(*Mocks)
+		expectedTrack   *model.Track
+		expectedLang    string
+		expectedCreated bool
+		expectedError   error
 	}{
 		{
 			name:   "successful create with cache hit",
@@ -58,35 +71,189 @@ func TestService_Save(t *testing.T) {
 			mockSetup: func(m *Mocks) {
 				m.cache.On("Track", mock.Anything, "Artist1", "Song1").
 					Return(&model.Track{
-						Artist: "Artist1",
-						Title:  "Song1",
+						Artist:       "Artist1",
+						Title:        "Song1",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+					}, nil)
+			},
+			expectedTrack: &model.Track{
+				Artist:       "Artist1",
+				Title:        "Song1",
+				Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+			},
+			expectedLang: testLang,
+		},
+		{
+			name:   "track already stored",
+			artist: "artist1",
+			title:  "song1",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Track", mock.Anything, "artist1", "song1").
+					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "artist1", "song1").
+					Return(&model.Track{
+						UUID:         "existing-uuid",
+						Artist:       "Artist1",
+						Title:        "Song1",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
 					}, nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
 			},
 			expectedTrack: &model.Track{
+				UUID:   "existing-uuid",
 				Artist: "Artist1",
 				Title:  "Song1",
 			},
+			expectedLang: testLang,
+		},
+		{
+			name:   "stored track translated into new language",
+			artist: "Artist1",
+			title:  "Song1",
+			lang:   "UK",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(&model.Track{
+						UUID:         "existing-uuid",
+						Artist:       "Artist1",
+						Title:        "Song1",
+						Lyrics:       []model.Stanza{{Lines: []string{"track"}}},
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+					}, nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, "uk").
+					Return([]string{"переклад"}, nil)
+				m.storage.On("SaveTranslation", mock.Anything, "existing-uuid", "uk", []model.Stanza{{Lines: []string{"переклад"}}}).
+					Return(nil)
+				m.cache.On("InvalidateTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTrack: &model.Track{
+				UUID:         "existing-uuid",
+				Artist:       "Artist1",
+				Title:        "Song1",
+				Lyrics:       []model.Stanza{{Lines: []string{"track"}}},
+				Translations: map[string][]model.Stanza{"uk": {{Lines: []string{"переклад"}}}},
+			},
+			expectedLang: "uk",
 		},
 		{
 			name:   "successful create with new track",
 			artist: "Artist2",
 			title:  "Song2",
+			lang:   "de",
 			mockSetup: func(m *Mocks) {
 				m.cache.On("Track", mock.Anything, "Artist2", "Song2").
 					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Artist2", "Song2").
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist2", "Song2").
+					Return(false, nil)
 				m.lyricsProvider.On("Lyrics", mock.Anything, "Artist2", "Song2").
-					Return([]string{"track"}, nil)
-				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}).
+					Return([]string{"track"}, "lyricsovh", nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, "de").
 					Return([]string{"translation"}, nil)
-				m.storage.On("SaveTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+				m.storage.On("SaveTrack", mock.Anything, mock.MatchedBy(func(t *model.Track) bool {
+					return t.UserID == 1 && t.Provider == "lyricsovh" && len(t.Translations["de"]) == 1
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*model.Track).UUID = "new-uuid"
+				}).Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist2").
 					Return(nil)
+				m.cache.On("InvalidateMisses", mock.Anything, "Artist2", "Song2").
+					Return(nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
 			},
 			expectedTrack: &model.Track{
-				Artist:      "Artist2",
-				Title:       "Song2",
-				Lyrics:      []string{"track"},
-				Translation: []string{"translation"},
+				UUID:         "new-uuid",
+				Artist:       "Artist2",
+				Title:        "Song2",
+				Lyrics:       []model.Stanza{{Lines: []string{"track"}}},
+				Translations: map[string][]model.Stanza{"de": {{Lines: []string{"translation"}}}},
 			},
+			expectedLang:    "de",
+			expectedCreated: true,
+		},
+		{
+			name:   "stanzas with section markers",
+			artist: "Artist4",
+			title:  "Song4",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Track", mock.Anything, "Artist4", "Song4").
+					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Artist4", "Song4").
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist4", "Song4").
+					Return(false, nil)
+				m.lyricsProvider.On("Lyrics", mock.Anything, "Artist4", "Song4").
+					Return([]string{"[Verse]", "one", "two", "", "[Chorus]", "three"}, "lyricsovh", nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"one", "two", "", "three"}, testLang).
+					Return([]string{"раз", "два", "", "три"}, nil)
+				m.storage.On("SaveTrack", mock.Anything, mock.MatchedBy(func(t *model.Track) bool {
+					return len(t.Lyrics) == 2 &&
+						t.Lyrics[1].Label == "Chorus" &&
+						t.Translations[testLang][1].Label == "Chorus"
+				})).Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist4").
+					Return(nil)
+				m.cache.On("InvalidateMisses", mock.Anything, "Artist4", "Song4").
+					Return(nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTrack: &model.Track{
+				Artist: "Artist4",
+				Title:  "Song4",
+				Lyrics: []model.Stanza{
+					{Label: "Verse", Lines: []string{"one", "two"}},
+					{Label: "Chorus", Lines: []string{"three"}},
+				},
+				Translations: map[string][]model.Stanza{testLang: {
+					{Label: "Verse", Lines: []string{"раз", "два"}},
+					{Label: "Chorus", Lines: []string{"три"}},
+				}},
+			},
+			expectedLang:    testLang,
+			expectedCreated: true,
+		},
+		{
+			name:   "track saved by concurrent request",
+			artist: "Artist3",
+			title:  "Song3",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Track", mock.Anything, "Artist3", "Song3").
+					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Artist3", "Song3").
+					Return(nil, storage.ErrTrackNotFound).Once()
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist3", "Song3").
+					Return(false, nil)
+				m.lyricsProvider.On("Lyrics", mock.Anything, "Artist3", "Song3").
+					Return([]string{"track"}, "lyricsovh", nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, testLang).
+					Return([]string{"translation"}, nil)
+				m.storage.On("SaveTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+					Return(storage.ErrTrackExists)
+				m.storage.On("Track", mock.Anything, "Artist3", "Song3").
+					Return(&model.Track{
+						UUID:         "concurrent-uuid",
+						Artist:       "Artist3",
+						Title:        "Song3",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"translation"}}}},
+					}, nil).Once()
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTrack: &model.Track{
+				UUID:   "concurrent-uuid",
+				Artist: "Artist3",
+				Title:  "Song3",
+			},
+			expectedLang: testLang,
 		},
 		{
 			name:   "track not found",
@@ -95,8 +262,28 @@ func TestService_Save(t *testing.T) {
 			mockSetup: func(m *Mocks) {
 				m.cache.On("Track", mock.Anything, "Unknown", "Song").
 					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Unknown", "Song").
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Unknown", "Song").
+					Return(false, nil)
 				m.lyricsProvider.On("Lyrics", mock.Anything, "Unknown", "Song").
-					Return(nil, ErrLyricsNotFound)
+					Return(nil, "", trackClient.ErrLyricsNotFound)
+				m.cache.On("SaveMiss", mock.Anything, storage.MissLyrics, "Unknown", "Song").
+					Return(nil)
+			},
+			expectedError: ErrLyricsNotFound,
+		},
+		{
+			name:   "lyrics cached as not found",
+			artist: "Unknown",
+			title:  "Song",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Track", mock.Anything, "Unknown", "Song").
+					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Unknown", "Song").
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Unknown", "Song").
+					Return(true, nil)
 			},
 			expectedError: ErrLyricsNotFound,
 		},
@@ -107,9 +294,13 @@ func TestService_Save(t *testing.T) {
 			mockSetup: func(m *Mocks) {
 				m.cache.On("Track", mock.Anything, "Artist", "Song").
 					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Artist", "Song").
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist", "Song").
+					Return(false, nil)
 				m.lyricsProvider.On("Lyrics", mock.Anything, "Artist", "Song").
-					Return([]string{"track"}, nil)
-				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}).
+					Return([]string{"track"}, "lyricsovh", nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, testLang).
 					Return(nil, ErrFailedTranslateLyrics)
 			},
 			expectedError: ErrFailedTranslateLyrics,
@@ -121,22 +312,25 @@ func TestService_Save(t *testing.T) {
 			s, m := setupService(t)
 			tt.mockSetup(m)
 
-			track, err := s.Save(context.Background(), tt.artist, tt.title)
+			track, created, err := s.Save(context.Background(), tt.artist, tt.title, tt.lang, 1)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
 				assert.Nil(t, track)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCreated, created)
 				assert.Equal(t, tt.expectedTrack.Artist, track.Artist)
 				assert.Equal(t, tt.expectedTrack.Title, track.Title)
+				assert.Equal(t, tt.expectedTrack.UUID, track.UUID)
+				assert.Equal(t, tt.expectedLang, track.Lang)
 
 				if tt.expectedTrack.Lyrics != nil {
-					assert.Equal(t, tt.expectedTrack.Lyrics, track.Lyrics)
+					assert.Equal(t, lyrics.Flatten(tt.expectedTrack.Lyrics), track.Lyrics)
 				}
 
-				if tt.expectedTrack.Translation != nil {
-					assert.Equal(t, tt.expectedTrack.Translation, track.Translation)
+				if translation, ok := tt.expectedTrack.Translations[tt.expectedLang]; ok {
+					assert.Equal(t, lyrics.Flatten(translation), track.Translation)
 				}
 			}
 
@@ -153,6 +347,7 @@ func TestService_Track(t *testing.T) {
 		name          string
 		artist        string
 		title         string
+		lang          string
 		mockSetup     func(*Mocks)
 		expectedTrack *model.Track
 		expectedError error
@@ -162,10 +357,13 @@ func TestService_Track(t *testing.T) {
 			artist: "Artist1",
 			title:  "Song1",
 			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissTrack, "Artist1", "Song1").
+					Return(false, nil)
 				m.cache.On("Track", mock.Anything, "Artist1", "Song1").
 					Return(&model.Track{
-						Artist: "Artist1",
-						Title:  "Song1",
+						Artist:       "Artist1",
+						Title:        "Song1",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
 					}, nil)
 			},
 			expectedTrack: &model.Track{
@@ -178,28 +376,82 @@ func TestService_Track(t *testing.T) {
 			artist: "Artist2",
 			title:  "Song2",
 			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissTrack, "Artist2", "Song2").
+					Return(false, nil)
 				m.cache.On("Track", mock.Anything, "Artist2", "Song2").
 					Return(nil, errors.New("not found"))
 				m.storage.On("Track", mock.Anything, "Artist2", "Song2").
 					Return(&model.Track{
-						Artist: "Artist2",
-						Title:  "Song2",
+						Artist:       "Artist2",
+						Title:        "Song2",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
 					}, nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
 			},
 			expectedTrack: &model.Track{
 				Artist: "Artist2",
 				Title:  "Song2",
 			},
 		},
+		{
+			name:   "missing translation added",
+			artist: "Artist2",
+			title:  "Song2",
+			lang:   "de",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissTrack, "Artist2", "Song2").
+					Return(false, nil)
+				m.cache.On("Track", mock.Anything, "Artist2", "Song2").
+					Return(nil, errors.New("not found"))
+				m.storage.On("Track", mock.Anything, "Artist2", "Song2").
+					Return(&model.Track{
+						UUID:         "uuid-2",
+						Artist:       "Artist2",
+						Title:        "Song2",
+						Lyrics:       []model.Stanza{{Lines: []string{"track"}}},
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+					}, nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, "de").
+					Return([]string{"Übersetzung"}, nil)
+				m.storage.On("SaveTranslation", mock.Anything, "uuid-2", "de", []model.Stanza{{Lines: []string{"Übersetzung"}}}).
+					Return(nil)
+				m.cache.On("InvalidateTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist2").
+					Return(nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTrack: &model.Track{
+				Artist:       "Artist2",
+				Title:        "Song2",
+				Translations: map[string][]model.Stanza{"de": {{Lines: []string{"Übersetzung"}}}},
+			},
+		},
 		{
 			name:   "track not found",
 			artist: "Unknown",
 			title:  "Song",
 			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissTrack, "Unknown", "Song").
+					Return(false, nil)
 				m.cache.On("Track", mock.Anything, "Unknown", "Song").
 					Return(nil, errors.New("not found"))
 				m.storage.On("Track", mock.Anything, "Unknown", "Song").
-					Return(nil, ErrTrackNotFound)
+					Return(nil, storage.ErrTrackNotFound)
+				m.cache.On("SaveMiss", mock.Anything, storage.MissTrack, "Unknown", "Song").
+					Return(nil)
+			},
+			expectedError: ErrTrackNotFound,
+		},
+		{
+			name:   "track cached as not found",
+			artist: "Unknown",
+			title:  "Song",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissTrack, "Unknown", "Song").
+					Return(true, nil)
 			},
 			expectedError: ErrTrackNotFound,
 		},
@@ -210,7 +462,7 @@ func TestService_Track(t *testing.T) {
 			s, m := setupService(t)
 			tt.mockSetup(m)
 
-			track, err := s.Track(context.Background(), tt.artist, tt.title)
+			track, err := s.Track(context.Background(), tt.artist, tt.title, tt.lang)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
@@ -219,6 +471,10 @@ func TestService_Track(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.expectedTrack.Artist, track.Artist)
 				assert.Equal(t, tt.expectedTrack.Title, track.Title)
+
+				if translation, ok := tt.expectedTrack.Translations[tt.lang]; ok {
+					assert.Equal(t, lyrics.Flatten(translation), track.Translation)
+				}
 			}
 
 			m.storage.AssertExpectations(t)
@@ -227,22 +483,265 @@ func TestService_Track(t *testing.T) {
 	}
 }
 
+func TestService_Suggestions(t *testing.T) {
+	tests := []struct {
+		name                string
+		mockSetup           func(*Mocks)
+		expectedSuggestions []*dto.SuggestionResponse
+		expectedError       bool
+	}{
+		{
+			name: "similar tracks",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SimilarTracks", mock.Anything, "juice world", "Lucid Dream", maxSuggestions).
+					Return([]*model.Suggestion{
+						{UUID: "uuid-1", Artist: "Juice WRLD", Title: "Lucid Dreams", Score: 0.72},
+					}, nil)
+			},
+			expectedSuggestions: []*dto.SuggestionResponse{
+				{UUID: "uuid-1", Artist: "Juice WRLD", Title: "Lucid Dreams", Score: 0.72},
+			},
+		},
+		{
+			name: "no similar tracks",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SimilarTracks", mock.Anything, "juice world", "Lucid Dream", maxSuggestions).
+					Return(nil, nil)
+			},
+			expectedSuggestions: []*dto.SuggestionResponse{},
+		},
+		{
+			name: "storage error",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SimilarTracks", mock.Anything, "juice world", "Lucid Dream", maxSuggestions).
+					Return(nil, errors.New("db error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			suggestions, err := s.Suggestions(context.Background(), "juice world", "Lucid Dream")
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, suggestions)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedSuggestions, suggestions)
+			}
+
+			m.storage.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_TrackByUUID(t *testing.T) {
+	translated := map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}}
+
+	tests := []struct {
+		name          string
+		uuid          string
+		mockSetup     func(*Mocks)
+		expectedTrack *model.Track
+		expectedError error
+	}{
+		{
+			name: "cache hit",
+			uuid: "uuid-1",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("TrackByUUID", mock.Anything, "uuid-1").
+					Return(&model.Track{UUID: "uuid-1", Artist: "Artist1", Title: "Song1", Translations: translated}, nil)
+			},
+			expectedTrack: &model.Track{UUID: "uuid-1", Artist: "Artist1", Title: "Song1"},
+		},
+		{
+			name: "storage hit",
+			uuid: "uuid-2",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("TrackByUUID", mock.Anything, "uuid-2").
+					Return(nil, errors.New("not cached"))
+				m.storage.On("TrackByUUID", mock.Anything, "uuid-2").
+					Return(&model.Track{UUID: "uuid-2", Artist: "Artist2", Title: "Song2", Translations: translated}, nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTrack: &model.Track{UUID: "uuid-2", Artist: "Artist2", Title: "Song2"},
+		},
+		{
+			name: "track not found",
+			uuid: "uuid-3",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("TrackByUUID", mock.Anything, "uuid-3").
+					Return(nil, errors.New("not cached"))
+				m.storage.On("TrackByUUID", mock.Anything, "uuid-3").
+					Return(nil, storage.ErrTrackNotFound)
+			},
+			expectedError: ErrTrackNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			track, err := s.TrackByUUID(context.Background(), tt.uuid, "")
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, track)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTrack.UUID, track.UUID)
+			assert.Equal(t, tt.expectedTrack.Title, track.Title)
+			assert.Equal(t, lyrics.Flatten(translated[testLang]), track.Translation)
+		})
+	}
+}
+
+func TestService_InvalidLang(t *testing.T) {
+	s, _ := setupService(t)
+
+	ctx := context.Background()
+
+	for _, lang := range []string{"english", "e", "en_us", "en-", "zh-hans-cn", "../ru"} {
+		_, _, err := s.Save(ctx, "Artist1", "Song1", lang, 1)
+		assert.ErrorIs(t, err, ErrInvalidLang, lang)
+
+		_, err = s.TrackByUUID(ctx, "uuid-1", lang)
+		assert.ErrorIs(t, err, ErrInvalidLang, lang)
+
+		_, err = s.LineAt(ctx, "uuid-1", lang, time.Second)
+		assert.ErrorIs(t, err, ErrInvalidLang, lang)
+	}
+
+	for _, lang := range []string{"uk", " EN ", "pt-br", "zh-hans"} {
+		normalized, err := s.lang(lang)
+		assert.NoError(t, err, lang)
+		assert.Equal(t, strings.ToLower(strings.TrimSpace(lang)), normalized)
+	}
+}
+
+func TestService_LineAt(t *testing.T) {
+	synced := &model.Track{
+		UUID: "uuid-1",
+		Lyrics: []model.Stanza{
+			{Label: "Chorus", Lines: []string{"one", "two"}, Times: []time.Duration{time.Second, 3 * time.Second}},
+		},
+		Translations: map[string][]model.Stanza{testLang: {{Label: "Chorus", Lines: []string{"раз", "два"}}}},
+	}
+
+	tests := []struct {
+		name             string
+		at               time.Duration
+		track            *model.Track
+		expectedResponse *dto.TrackLineResponse
+		expectedError    error
+	}{
+		{
+			name:  "line with translation",
+			at:    1500 * time.Millisecond,
+			track: synced,
+			expectedResponse: &dto.TrackLineResponse{
+				UUID:     "uuid-1",
+				Lang:     testLang,
+				Position: 1.5,
+				Line: &dto.TimedLineResponse{
+					Label:       "Chorus",
+					Original:    "one",
+					Translation: "раз",
+					Start:       1,
+					End:         3,
+				},
+			},
+		},
+		{
+			name:  "last line",
+			at:    time.Minute,
+			track: synced,
+			expectedResponse: &dto.TrackLineResponse{
+				UUID:     "uuid-1",
+				Lang:     testLang,
+				Position: 60,
+				Line: &dto.TimedLineResponse{
+					Index:       1,
+					Label:       "Chorus",
+					Original:    "two",
+					Translation: "два",
+					Start:       3,
+				},
+			},
+		},
+		{
+			name:  "before the first line",
+			at:    0,
+			track: synced,
+			expectedResponse: &dto.TrackLineResponse{
+				UUID: "uuid-1",
+				Lang: testLang,
+			},
+		},
+		{
+			name: "lyrics without timing",
+			at:   time.Second,
+			track: &model.Track{
+				UUID:   "uuid-1",
+				Lyrics: []model.Stanza{{Lines: []string{"one"}}},
+			},
+			expectedError: ErrNotSynced,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+
+			m.cache.On("TrackByUUID", mock.Anything, "uuid-1").Return(tt.track, nil)
+
+			response, err := s.LineAt(context.Background(), "uuid-1", "", tt.at)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, response)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
 func TestService_ArtistTracks(t *testing.T) {
+	next := &model.Cursor{SortBy: model.SortByTitle, Key: "Song2", UUID: "e434dc13-ada5-4bde-b695-d97014dadebc"}
+	nextCursor := cursor.Encode(next)
+
 	tests := []struct {
-		name           string
-		artist         string
-		mockSetup      func(*Mocks)
-		expectedTracks []*model.Track
-		expectedError  error
+		name               string
+		artist             string
+		req                dto.PageRequest
+		mockSetup          func(*Mocks)
+		expectedTracks     []*model.Track
+		expectedNextCursor string
+		expectedError      error
 	}{
 		{
 			name:   "cache hit",
 			artist: "Artist1",
 			mockSetup: func(m *Mocks) {
-				m.cache.On("ArtistTracks", mock.Anything, "Artist1").
-					Return([]*model.Track{
+				m.cache.On("Missed", mock.Anything, storage.MissArtistTracks, "Artist1", "").
+					Return(false, nil)
+				m.cache.On("ArtistTracks", mock.Anything, "Artist1", "title:false:20:false:").
+					Return(&model.TrackPage{Tracks: []*model.Track{
 						{Artist: "Artist1", Title: "Song1"},
-					}, nil)
+					}}, nil)
 			},
 			expectedTracks: []*model.Track{
 				{Artist: "Artist1", Title: "Song1"},
@@ -251,28 +750,86 @@ func TestService_ArtistTracks(t *testing.T) {
 		{
 			name:   "storage hit",
 			artist: "Artist2",
+			req:    dto.PageRequest{Limit: 2},
 			mockSetup: func(m *Mocks) {
-				m.cache.On("ArtistTracks", mock.Anything, "Artist2").
+				m.cache.On("Missed", mock.Anything, storage.MissArtistTracks, "Artist2", "").
+					Return(false, nil)
+				m.cache.On("ArtistTracks", mock.Anything, "Artist2", "title:false:2:false:").
 					Return(nil, errors.New("not found"))
-				m.storage.On("TracksByArtist", mock.Anything, "Artist2").
-					Return([]*model.Track{
-						{Artist: "Artist2", Title: "Song1"},
-						{Artist: "Artist2", Title: "Song2"},
+				m.storage.On("TracksByArtist", mock.Anything, "Artist2",
+					model.PageQuery{Limit: 2, SortBy: model.SortByTitle}).
+					Return(&model.TrackPage{
+						Tracks: []*model.Track{
+							{Artist: "Artist2", Title: "Song1"},
+							{Artist: "Artist2", Title: "Song2"},
+						},
+						Next: next,
 					}, nil)
+				m.cache.On("SaveArtistTracks", mock.Anything, "Artist2", "title:false:2:false:", mock.Anything).
+					Return(nil).Maybe()
 			},
 			expectedTracks: []*model.Track{
 				{Artist: "Artist2", Title: "Song1"},
 				{Artist: "Artist2", Title: "Song2"},
 			},
+			expectedNextCursor: nextCursor,
+		},
+		{
+			name:   "next page",
+			artist: "Artist2",
+			req:    dto.PageRequest{Limit: 2, Cursor: nextCursor, Fields: dto.FieldsSummary},
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissArtistTracks, "Artist2", "").
+					Return(false, nil)
+				m.cache.On("ArtistTracks", mock.Anything, "Artist2", "title:false:2:true:"+nextCursor).
+					Return(nil, errors.New("not found"))
+				m.storage.On("TracksByArtist", mock.Anything, "Artist2",
+					model.PageQuery{Limit: 2, SortBy: model.SortByTitle, After: next, Summary: true}).
+					Return(&model.TrackPage{Tracks: []*model.Track{
+						{Artist: "Artist2", Title: "Song3"},
+					}}, nil)
+				m.cache.On("SaveArtistTracks", mock.Anything, "Artist2", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedTracks: []*model.Track{
+				{Artist: "Artist2", Title: "Song3"},
+			},
+		},
+		{
+			name:          "cursor of another order",
+			artist:        "Artist2",
+			req:           dto.PageRequest{Sort: "-created_at", Cursor: nextCursor},
+			mockSetup:     func(m *Mocks) {},
+			expectedError: ErrInvalidCursor,
+		},
+		{
+			name:          "malformed cursor",
+			artist:        "Artist2",
+			req:           dto.PageRequest{Cursor: "broken"},
+			mockSetup:     func(m *Mocks) {},
+			expectedError: ErrInvalidCursor,
 		},
 		{
 			name:   "artist not found",
 			artist: "Unknown",
 			mockSetup: func(m *Mocks) {
-				m.cache.On("ArtistTracks", mock.Anything, "Unknown").
+				m.cache.On("Missed", mock.Anything, storage.MissArtistTracks, "Unknown", "").
+					Return(false, nil)
+				m.cache.On("ArtistTracks", mock.Anything, "Unknown", mock.Anything).
 					Return(nil, errors.New("not found"))
-				m.storage.On("TracksByArtist", mock.Anything, "Unknown").
-					Return(nil, ErrArtistTracksNotFound)
+				m.storage.On("TracksByArtist", mock.Anything, "Unknown", mock.Anything).
+					Return(nil, storage.ErrArtistTracksNotFound)
+				m.cache.On("SaveMiss", mock.Anything, storage.MissArtistTracks, "Unknown", "").
+					Return(nil)
+			},
+			expectedError: ErrArtistTracksNotFound,
+		},
+		{
+			name:   "artist cached as not found",
+			artist: "Unknown",
+			mockSetup: func(m *Mocks) {
+				m.cache.On("Missed", mock.Anything, storage.MissArtistTracks, "Unknown", "").
+					Return(true, nil)
 			},
 			expectedError: ErrArtistTracksNotFound,
 		},
@@ -283,17 +840,18 @@ func TestService_ArtistTracks(t *testing.T) {
 			s, m := setupService(t)
 			tt.mockSetup(m)
 
-			tracks, err := s.ArtistTracks(context.Background(), tt.artist)
+			page, err := s.ArtistTracks(context.Background(), tt.artist, "", tt.req)
 
 			if tt.expectedError != nil {
 				assert.ErrorIs(t, err, tt.expectedError)
-				assert.Nil(t, tracks)
+				assert.Nil(t, page)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, len(tt.expectedTracks), len(tracks))
-				for i := range tracks {
-					assert.Equal(t, tt.expectedTracks[i].Artist, tracks[i].Artist)
-					assert.Equal(t, tt.expectedTracks[i].Title, tracks[i].Title)
+				assert.Equal(t, tt.expectedNextCursor, page.NextCursor)
+				assert.Equal(t, len(tt.expectedTracks), len(page.Tracks))
+				for i := range page.Tracks {
+					assert.Equal(t, tt.expectedTracks[i].Artist, page.Tracks[i].Artist)
+					assert.Equal(t, tt.expectedTracks[i].Title, page.Tracks[i].Title)
 				}
 			}
 
@@ -303,36 +861,422 @@ func TestService_ArtistTracks(t *testing.T) {
 	}
 }
 
+func TestService_UserTracks(t *testing.T) {
+	tests := []struct {
+		name           string
+		userID         int64
+		mockSetup      func(*Mocks)
+		expectedTracks []*model.Track
+		expectedError  bool
+	}{
+		{
+			name:   "user has tracks",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TracksByUser", mock.Anything, int64(1)).
+					Return([]*model.Track{
+						{UserID: 1, Artist: "Artist1", Title: "Song1"},
+					}, nil)
+			},
+			expectedTracks: []*model.Track{
+				{UserID: 1, Artist: "Artist1", Title: "Song1"},
+			},
+		},
+		{
+			name:   "user has no tracks",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TracksByUser", mock.Anything, int64(2)).
+					Return(nil, nil)
+			},
+			expectedTracks: []*model.Track{},
+		},
+		{
+			name:   "storage error",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TracksByUser", mock.Anything, int64(1)).
+					Return(nil, errors.New("storage error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			tracks, err := s.UserTracks(context.Background(), tt.userID)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, tracks)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, len(tt.expectedTracks), len(tracks))
+			for i := range tracks {
+				assert.Equal(t, tt.expectedTracks[i].Title, tracks[i].Title)
+			}
+		})
+	}
+}
+
+func TestService_Export(t *testing.T) {
+	filter := model.ExportFilter{Artist: "Artist1", UserID: 1}
+	tracks := []*model.Track{
+		{UUID: "uuid1", Artist: "Artist1", Title: "Song1"},
+		{UUID: "uuid2", Artist: "Artist1", Title: "Song2"},
+	}
+
+	tests := []struct {
+		name          string
+		fnErr         error
+		storageErr    error
+		expectedUUIDs []string
+		expectedError error
+	}{
+		{
+			name:          "all tracks exported",
+			expectedUUIDs: []string{"uuid1", "uuid2"},
+		},
+		{
+			name:          "write error stops export",
+			fnErr:         errors.New("broken pipe"),
+			expectedUUIDs: []string{"uuid1"},
+			expectedError: errors.New("broken pipe"),
+		},
+		{
+			name:          "storage error",
+			storageErr:    errors.New("storage error"),
+			expectedError: errors.New("storage error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+
+			m.storage.On("ExportTracks", mock.Anything, filter, mock.Anything).
+				Return(func(_ context.Context, _ model.ExportFilter, fn func(*model.Track) error) error {
+					if tt.storageErr != nil {
+						return tt.storageErr
+					}
+
+					for _, track := range tracks {
+						if err := fn(track); err != nil {
+							return err
+						}
+					}
+
+					return nil
+				})
+
+			var uuids []string
+
+			err := s.Export(context.Background(), filter, func(track *model.Track) error {
+				uuids = append(uuids, track.UUID)
+				return tt.fnErr
+			})
+
+			if tt.expectedError != nil {
+				assert.ErrorContains(t, err, tt.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedUUIDs, uuids)
+		})
+	}
+}
+
+func TestService_Search(t *testing.T) {
+	tests := []struct {
+		name            string
+		lang            string
+		mockSetup       func(*Mocks)
+		expectedResults []*dto.SearchResultResponse
+		expectedError   bool
+	}{
+		{
+			name: "found in translation",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SearchTracks", mock.Anything, "тени", testLang, 10).
+					Return([]*model.SearchResult{
+						{
+							Track: &model.Track{
+								UUID:         "uuid-1",
+								Artist:       "Artist1",
+								Title:        "Song1",
+								Lyrics:       []model.Stanza{{Lines: []string{"shadows"}}},
+								Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"тени"}}}},
+							},
+							Rank:      0.6,
+							Snippet:   "<mark>тени</mark>",
+							MatchLang: testLang,
+						},
+					}, nil)
+			},
+			expectedResults: []*dto.SearchResultResponse{
+				{
+					Track: &dto.TrackResponse{
+						UUID:        "uuid-1",
+						Artist:      "Artist1",
+						Title:       "Song1",
+						Lang:        testLang,
+						Lyrics:      []string{"shadows"},
+						Translation: []string{"тени"},
+						Stanzas: []*dto.StanzaResponse{
+							{Lines: []string{"shadows"}, Translation: []string{"тени"}},
+						},
+					},
+					Rank:      0.6,
+					Snippet:   "<mark>тени</mark>",
+					MatchLang: testLang,
+				},
+			},
+		},
+		{
+			name: "storage error",
+			lang: "DE",
+			mockSetup: func(m *Mocks) {
+				m.storage.On("SearchTracks", mock.Anything, "тени", "de", 10).
+					Return(nil, errors.New("database error"))
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			results, err := s.Search(context.Background(), "тени", tt.lang, 10)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				assert.Nil(t, results)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResults, results)
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	updated := &model.Track{
+		UUID:         "uuid-1",
+		UserID:       1,
+		Artist:       "Artist1",
+		Title:        "Song1",
+		Lyrics:       []model.Stanza{{Lines: []string{"line"}}},
+		Translations: map[string][]model.Stanza{"uk": {{Lines: []string{"рядок"}}}},
+	}
+
+	stored := &model.Track{
+		UUID:   "uuid-1",
+		UserID: 1,
+		Lyrics: []model.Stanza{{Label: "Chorus", Lines: []string{"line"}, Times: []time.Duration{time.Second}}},
+	}
+
+	tests := []struct {
+		name          string
+		userID        int64
+		lyrics        []string
+		translation   []string
+		mockSetup     func(*Mocks)
+		expectedError error
+	}{
+		{
+			name:        "successful update",
+			userID:      1,
+			translation: []string{"рядок"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TrackByUUID", mock.Anything, "uuid-1").
+					Return(stored, nil)
+				m.storage.On("UpdateTrack", mock.Anything, "uuid-1", int64(1), []model.Stanza(nil), "uk",
+					[]model.Stanza{{Label: "Chorus", Lines: []string{"рядок"}, Times: []time.Duration{time.Second}}}).
+					Return(updated, nil)
+				m.cache.On("InvalidateTrack", mock.Anything, updated).
+					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+			},
+		},
+		{
+			name:        "lyrics with translation",
+			userID:      1,
+			lyrics:      []string{"[Verse]", "line 1", "", "line 2"},
+			translation: []string{"[Куплет]", "рядок 1", "", "рядок 2"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("UpdateTrack", mock.Anything, "uuid-1", int64(1),
+					[]model.Stanza{{Label: "Verse", Lines: []string{"line 1"}}, {Lines: []string{"line 2"}}}, "uk",
+					[]model.Stanza{{Label: "Verse", Lines: []string{"рядок 1"}}, {Lines: []string{"рядок 2"}}}).
+					Return(updated, nil)
+				m.cache.On("InvalidateTrack", mock.Anything, updated).
+					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+			},
+		},
+		{
+			name:        "translation not matching lyrics",
+			userID:      1,
+			translation: []string{"рядок", "зайвий рядок"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TrackByUUID", mock.Anything, "uuid-1").
+					Return(stored, nil)
+			},
+			expectedError: ErrInvalidTranslation,
+		},
+		{
+			name:        "translation of missing track",
+			userID:      1,
+			translation: []string{"рядок"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("TrackByUUID", mock.Anything, "uuid-1").
+					Return(nil, storage.ErrTrackNotFound)
+			},
+			expectedError: ErrTrackNotFound,
+		},
+		{
+			name:   "track not found",
+			userID: 1,
+			lyrics: []string{"line"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("UpdateTrack", mock.Anything, "uuid-1", int64(1), mock.Anything, "uk", []model.Stanza(nil)).
+					Return(nil, storage.ErrTrackNotFound)
+			},
+			expectedError: ErrTrackNotFound,
+		},
+		{
+			name:   "track of another user",
+			userID: 2,
+			lyrics: []string{"line"},
+			mockSetup: func(m *Mocks) {
+				m.storage.On("UpdateTrack", mock.Anything, "uuid-1", int64(2), mock.Anything, "uk", []model.Stanza(nil)).
+					Return(nil, storage.ErrNotTrackOwner)
+			},
+			expectedError: ErrNotTrackOwner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, m := setupService(t)
+			tt.mockSetup(m)
+
+			track, err := s.Update(context.Background(), "uuid-1", tt.userID, tt.lyrics, "uk", tt.translation)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, track)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, "uk", track.Lang)
+			assert.Equal(t, lyrics.Flatten(updated.Translations["uk"]), track.Translation)
+
+			m.cache.AssertExpectations(t)
+		})
+	}
+}
+
 func TestService_Delete(t *testing.T) {
+	deleted := &model.Track{UUID: "valid-uuid", Artist: "Artist1", Title: "Song1"}
+
 	tests := []struct {
 		name          string
 		uuid          string
+		userID        int64
+		admin         bool
 		mockSetup     func(*Mocks)
 		expectedError error
 	}{
 		{
-			name: "successful delete",
-			uuid: "valid-uuid",
+			name:   "successful delete",
+			uuid:   "valid-uuid",
+			userID: 1,
 			mockSetup: func(m *Mocks) {
-				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid").
+				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid", int64(1), false).
+					Return(deleted, nil)
+				m.cache.On("InvalidateTrack", mock.Anything, deleted).
 					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+			},
+		},
+		{
+			name:   "deleted despite failed eviction",
+			uuid:   "valid-uuid",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid", int64(1), false).
+					Return(deleted, nil)
+				m.cache.On("InvalidateTrack", mock.Anything, deleted).
+					Return(errors.New("redis is down"))
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(errors.New("redis is down"))
 			},
 		},
 		{
-			name: "invalid uuid",
-			uuid: "invalid-uuid",
+			name:   "invalid uuid",
+			uuid:   "invalid-uuid",
+			userID: 1,
 			mockSetup: func(m *Mocks) {
-				m.storage.On("DeleteTrack", mock.Anything, "invalid-uuid").
-					Return(ErrInvalidUUID)
+				m.storage.On("DeleteTrack", mock.Anything, "invalid-uuid", int64(1), false).
+					Return(nil, storage.ErrInvalidUUID)
 			},
 			expectedError: ErrInvalidUUID,
 		},
 		{
-			name: "storage error",
-			uuid: "valid-uuid",
+			name:   "track of another user",
+			uuid:   "valid-uuid",
+			userID: 2,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid", int64(2), false).
+					Return(nil, storage.ErrNotTrackOwner)
+			},
+			expectedError: ErrNotTrackOwner,
+		},
+		{
+			name:   "admin deletes track of another user",
+			uuid:   "valid-uuid",
+			userID: 2,
+			admin:  true,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid", int64(2), true).
+					Return(deleted, nil)
+				m.cache.On("InvalidateTrack", mock.Anything, deleted).
+					Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+			},
+		},
+		{
+			name:   "admin deletes missing track",
+			uuid:   "missing-uuid",
+			userID: 2,
+			admin:  true,
 			mockSetup: func(m *Mocks) {
-				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid").
-					Return(errors.New("storage error"))
+				m.storage.On("DeleteTrack", mock.Anything, "missing-uuid", int64(2), true).
+					Return(nil, storage.ErrInvalidUUID)
+			},
+			expectedError: ErrInvalidUUID,
+		},
+		{
+			name:   "storage error",
+			uuid:   "valid-uuid",
+			userID: 1,
+			mockSetup: func(m *Mocks) {
+				m.storage.On("DeleteTrack", mock.Anything, "valid-uuid", int64(1), false).
+					Return(nil, errors.New("storage error"))
 			},
 			expectedError: errors.New("storage error"),
 		},
@@ -343,18 +1287,233 @@ func TestService_Delete(t *testing.T) {
 			s, m := setupService(t)
 			tt.mockSetup(m)
 
-			err := s.Delete(context.Background(), tt.uuid)
+			err := s.Delete(context.Background(), tt.uuid, tt.userID, tt.admin)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
-				if errors.Is(tt.expectedError, ErrInvalidUUID) {
-					assert.ErrorIs(t, err, ErrInvalidUUID)
+				if errors.Is(tt.expectedError, ErrInvalidUUID) || errors.Is(tt.expectedError, ErrNotTrackOwner) {
+					assert.ErrorIs(t, err, tt.expectedError)
 				}
 			} else {
 				assert.NoError(t, err)
 			}
 
 			m.storage.AssertExpectations(t)
+			m.cache.AssertExpectations(t)
+		})
+	}
+}
+
+func TestService_SaveAfterDelete(t *testing.T) {
+	s, m := setupService(t)
+
+	track := &model.Track{
+		UUID:         "deleted-uuid",
+		UserID:       1,
+		Artist:       "Artist1",
+		Title:        "Song1",
+		Lyrics:       []model.Stanza{{Lines: []string{"track"}}},
+		Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+	}
+
+	// the cache serves the track until it's evicted
+	evicted := false
+	m.cache.On("Track", mock.Anything, "Artist1", "Song1").
+		Return(track, nil).Once()
+	m.cache.On("Track", mock.Anything, "Artist1", "Song1").
+		Run(func(mock.Arguments) {
+			assert.True(t, evicted, "cache is read again before eviction")
+		}).Return(nil, storage.ErrTrackNotCached).Once()
+	m.cache.On("InvalidateTrack", mock.Anything, mock.MatchedBy(func(t *model.Track) bool {
+		return t.UUID == "deleted-uuid"
+	})).Run(func(mock.Arguments) {
+		evicted = true
+	}).Return(nil)
+	m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+		Return(nil)
+	m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+
+	m.storage.On("DeleteTrack", mock.Anything, "deleted-uuid", int64(1), false).
+		Return(&model.Track{UUID: "deleted-uuid", Artist: "Artist1", Title: "Song1"}, nil)
+	m.storage.On("Track", mock.Anything, "Artist1", "Song1").
+		Return(nil, storage.ErrTrackNotFound)
+	m.storage.On("SaveTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*model.Track).UUID = "new-uuid"
+		}).Return(nil)
+
+	m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist1", "Song1").
+		Return(false, nil)
+	m.cache.On("InvalidateMisses", mock.Anything, "Artist1", "Song1").
+		Return(nil)
+
+	m.lyricsProvider.On("Lyrics", mock.Anything, "Artist1", "Song1").
+		Return([]string{"track"}, "lyricsovh", nil)
+	m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, testLang).
+		Return([]string{"перевод"}, nil)
+
+	cached, created, err := s.Save(context.Background(), "Artist1", "Song1", "", 1)
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "deleted-uuid", cached.UUID)
+
+	assert.NoError(t, s.Delete(context.Background(), "deleted-uuid", 1, false))
+
+	saved, created, err := s.Save(context.Background(), "Artist1", "Song1", "", 1)
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "new-uuid", saved.UUID)
+
+	m.storage.AssertExpectations(t)
+	m.cache.AssertExpectations(t)
+}
+
+func TestService_SaveCoalescing(t *testing.T) {
+	s, m := setupService(t)
+
+	release := make(chan struct{})
+
+	m.cache.On("Track", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, storage.ErrTrackNotCached).Once()
+	m.storage.On("Track", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, storage.ErrTrackNotFound).Once()
+	m.cache.On("Missed", mock.Anything, storage.MissLyrics, mock.Anything, mock.Anything).
+		Return(false, nil).Once()
+	m.lyricsProvider.On("Lyrics", mock.Anything, mock.Anything, "Song1").
+		Run(func(mock.Arguments) { <-release }).
+		Return([]string{"track"}, "lyricsovh", nil).Once()
+	m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, testLang).
+		Return([]string{"перевод"}, nil).Once()
+	var owner int64
+	m.storage.On("SaveTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+		Run(func(args mock.Arguments) {
+			track := args.Get(1).(*model.Track)
+			track.UUID = "new-uuid"
+			owner = track.UserID
+		}).Return(nil).Once()
+	m.cache.On("InvalidateArtistTracks", mock.Anything, mock.Anything).
+		Return(nil).Once()
+	m.cache.On("InvalidateMisses", mock.Anything, mock.Anything, "Song1").
+		Return(nil).Once()
+	m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+		Return(nil).Maybe()
+
+	const requests = 5
+
+	var wg sync.WaitGroup
+	uuids := make([]string, requests)
+	created := make([]bool, requests)
+
+	for i := range requests {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			// spellings normalized to the same track share the save
+			artist := "Artist1"
+			if i%2 == 1 {
+				artist = "artist1"
+			}
+
+			track, ok, err := s.Save(context.Background(), artist, "Song1", "", int64(i+1))
+			assert.NoError(t, err)
+			if track != nil {
+				uuids[i] = track.UUID
+			}
+			created[i] = ok
+		}()
+	}
+
+	// let every request join the flight of the first one
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	wg.Wait()
+
+	for i, uuid := range uuids {
+		assert.Equal(t, "new-uuid", uuid)
+		// only the user the track was saved for created it
+		assert.Equal(t, int64(i+1) == owner, created[i], "user %d", i+1)
+	}
+}
+
+func TestService_SaveWithLock(t *testing.T) {
+	tests := []struct {
+		name            string
+		mockSetup       func(*Mocks, *mocks.Locker)
+		expectedUUID    string
+		expectedCreated bool
+	}{
+		{
+			name: "saved by another replica while locked",
+			mockSetup: func(m *Mocks, l *mocks.Locker) {
+				m.cache.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(nil, storage.ErrTrackNotCached)
+				m.storage.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(nil, storage.ErrTrackNotFound).Once()
+				l.On("Lock", mock.Anything, "save:artist1:song1").
+					Return(func(context.Context) error { return nil }, nil)
+				m.storage.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(&model.Track{
+						UUID:         "replica-uuid",
+						Artist:       "Artist1",
+						Title:        "Song1",
+						Translations: map[string][]model.Stanza{testLang: {{Lines: []string{"перевод"}}}},
+					}, nil).Once()
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedUUID: "replica-uuid",
+		},
+		{
+			name: "saved without lock when it can't be taken",
+			mockSetup: func(m *Mocks, l *mocks.Locker) {
+				m.cache.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(nil, storage.ErrTrackNotCached)
+				m.storage.On("Track", mock.Anything, "Artist1", "Song1").
+					Return(nil, storage.ErrTrackNotFound).Once()
+				l.On("Lock", mock.Anything, "save:artist1:song1").
+					Return(nil, storage.ErrLockNotAcquired)
+				m.cache.On("Missed", mock.Anything, storage.MissLyrics, "Artist1", "Song1").
+					Return(false, nil)
+				m.lyricsProvider.On("Lyrics", mock.Anything, "Artist1", "Song1").
+					Return([]string{"track"}, "lyricsovh", nil)
+				m.lyricsTranslator.On("TranslateLyrics", mock.Anything, []string{"track"}, testLang).
+					Return([]string{"перевод"}, nil)
+				m.storage.On("SaveTrack", mock.Anything, mock.AnythingOfType("*model.Track")).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*model.Track).UUID = "new-uuid"
+					}).Return(nil)
+				m.cache.On("InvalidateArtistTracks", mock.Anything, "Artist1").
+					Return(nil)
+				m.cache.On("InvalidateMisses", mock.Anything, "Artist1", "Song1").
+					Return(nil)
+				m.cache.On("SaveTrack", mock.Anything, mock.Anything).
+					Return(nil).Maybe()
+			},
+			expectedUUID:    "new-uuid",
+			expectedCreated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, m := setupService(t)
+			l := new(mocks.Locker)
+			tt.mockSetup(m, l)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+			s := New(log, m.lyricsProvider, m.lyricsTranslator, m.storage, m.cache, l, testLang)
+
+			track, created, err := s.Save(context.Background(), "Artist1", "Song1", "", 1)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUUID, track.UUID)
+			assert.Equal(t, tt.expectedCreated, created)
+
+			l.AssertExpectations(t)
 		})
 	}
 }
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"lyrics-library/internal/storage"
+)
+
+// Storage is an in-process LRU cache of validated tokens, once it holds size
+// tokens the least recently used one is evicted for a new one
+type Storage struct {
+	mu     sync.Mutex
+	size   int
+	order  *list.List
+	tokens map[string]*list.Element
+	now    func() time.Time
+}
+
+type token struct {
+	hash      string
+	userID    int64
+	expiresAt time.Time
+}
+
+func New(size int) *Storage {
+	return &Storage{
+		size:   size,
+		order:  list.New(),
+		tokens: make(map[string]*list.Element, size),
+		now:    time.Now,
+	}
+}
+
+func (s *Storage) SaveToken(_ context.Context, hash string, userID int64, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t := &token{hash: hash, userID: userID, expiresAt: s.now().Add(ttl)}
+
+	if e, ok := s.tokens[hash]; ok {
+		e.Value = t
+		s.order.MoveToFront(e)
+
+		return nil
+	}
+
+	s.tokens[hash] = s.order.PushFront(t)
+
+	for s.order.Len() > s.size {
+		s.remove(s.order.Back())
+	}
+
+	return nil
+}
+
+func (s *Storage) Token(_ context.Context, hash string) (int64, error) {
+	const op = "storage.memory.Token"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.tokens[hash]
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrTokenNotCached)
+	}
+
+	t := e.Value.(*token)
+	if !s.now().Before(t.expiresAt) {
+		s.remove(e)
+
+		return 0, fmt.Errorf("%s: %w", op, storage.ErrTokenNotCached)
+	}
+
+	s.order.MoveToFront(e)
+
+	return t.userID, nil
+}
+
+func (s *Storage) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.tokens, e.Value.(*token).hash)
+}
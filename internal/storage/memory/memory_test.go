@@ -0,0 +1,44 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/storage"
+)
+
+func TestStorage_Token(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	s := New(2)
+	s.now = func() time.Time { return now }
+
+	assert.NoError(t, s.SaveToken(ctx, "a", 1, time.Minute))
+	assert.NoError(t, s.SaveToken(ctx, "b", 2, time.Hour))
+
+	userID, err := s.Token(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), userID)
+
+	// b is the least recently used now
+	assert.NoError(t, s.SaveToken(ctx, "c", 3, time.Hour))
+
+	_, err = s.Token(ctx, "b")
+	assert.ErrorIs(t, err, storage.ErrTokenNotCached)
+
+	now = now.Add(time.Minute)
+
+	_, err = s.Token(ctx, "a")
+	assert.ErrorIs(t, err, storage.ErrTokenNotCached, "expired token")
+
+	userID, err = s.Token(ctx, "c")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), userID)
+	assert.Equal(t, 1, s.order.Len())
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/storage"
+)
+
+// SaveAPIKey stores the key by its hash and sets its id and creation time,
+// ErrAPIKeyExists is returned if the user has a key with the same name
+func (s *Storage) SaveAPIKey(ctx context.Context, key *model.APIKey, hash []byte) error {
+	const op = "storage.postgres.SaveAPIKey"
+
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO api_keys (user_id, name, prefix, key_hash)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING id, created_at
+	`, key.UserID, key.Name, key.Prefix, hash,
+	).Scan(&key.ID, &key.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrAPIKeyExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// UserAPIKeys returns keys of the user, the oldest first
+func (s *Storage) UserAPIKeys(ctx context.Context, userID int64) ([]*model.APIKey, error) {
+	const op = "storage.postgres.UserAPIKeys"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_id, name, prefix, created_at, last_used_at
+		FROM api_keys
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []*model.APIKey
+
+	for rows.Next() {
+		var (
+			key      model.APIKey
+			lastUsed sql.NullTime
+		)
+
+		if err := rows.Scan(
+			&key.ID,
+			&key.UserID,
+			&key.Name,
+			&key.Prefix,
+			&key.CreatedAt,
+			&lastUsed,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if lastUsed.Valid {
+			key.LastUsedAt = &lastUsed.Time
+		}
+
+		keys = append(keys, &key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return keys, nil
+}
+
+// DeleteAPIKey removes the key of the user, ErrAPIKeyNotFound is returned
+// if the user has no key with the id
+func (s *Storage) DeleteAPIKey(ctx context.Context, id string, userID int64) error {
+	const op = "storage.postgres.DeleteAPIKey"
+
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM api_keys WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		if isInvalidUUID(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrAPIKeyNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrAPIKeyNotFound)
+	}
+
+	return nil
+}
+
+// APIKeyOwner returns the owner of the key with the hash, ErrAPIKeyNotFound
+// is returned if there is no such key
+func (s *Storage) APIKeyOwner(ctx context.Context, hash []byte) (int64, error) {
+	const op = "storage.postgres.APIKeyOwner"
+
+	var userID int64
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_id FROM api_keys WHERE key_hash = $1
+	`, hash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrAPIKeyNotFound)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+// TouchAPIKey marks the key with the hash as used. The key row is written at
+// most once a minute, so requests authenticated by the key don't queue on its lock
+func (s *Storage) TouchAPIKey(ctx context.Context, hash []byte) error {
+	const op = "storage.postgres.TouchAPIKey"
+
+	_, err := s.db.ExecContext(ctx, `
+		UPDATE api_keys
+		SET last_used_at = now()
+		WHERE key_hash = $1
+		  AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute')
+	`, hash)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
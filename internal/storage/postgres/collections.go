@@ -0,0 +1,397 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/storage"
+)
+
+const selectCollections = `
+	SELECT c.id, c.user_id, c.name, c.slug, c.created_at, c.updated_at
+	FROM collections c
+`
+
+// SaveCollection stores a new collection and sets its id and timestamps,
+// ErrCollectionExists is returned if the user has a collection with the same name
+func (s *Storage) SaveCollection(ctx context.Context, collection *model.Collection) error {
+	const op = "storage.postgres.SaveCollection"
+
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO collections (user_id, name)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, name) DO NOTHING
+		RETURNING id, created_at, updated_at
+	`, collection.UserID, collection.Name,
+	).Scan(&collection.ID, &collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCollectionExists)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Collection returns the collection with its tracks in order
+func (s *Storage) Collection(ctx context.Context, id string) (*model.Collection, error) {
+	const op = "storage.postgres.Collection"
+
+	collection, err := s.collection(ctx, `WHERE c.id = $1`, id)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+// CollectionBySlug returns the shared collection with its tracks in order
+func (s *Storage) CollectionBySlug(ctx context.Context, slug string) (*model.Collection, error) {
+	const op = "storage.postgres.CollectionBySlug"
+
+	collection, err := s.collection(ctx, `WHERE c.slug = $1`, slug)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collection, nil
+}
+
+func (s *Storage) collection(ctx context.Context, where string, arg any) (*model.Collection, error) {
+	collection, err := scanCollection(s.db.QueryRowContext(ctx, selectCollections+where, arg))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isInvalidUUID(err) {
+			return nil, storage.ErrCollectionNotFound
+		}
+
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx, selectTrackSummaries+`
+		JOIN collection_tracks ct ON ct.song_uuid = s.uuid
+		WHERE ct.collection_id = $1
+		ORDER BY ct.position, ct.added_at
+	`, collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Tracks, err = scanTracks(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.TrackCount = len(collection.Tracks)
+
+	return collection, nil
+}
+
+// UserCollections returns collections of the user without tracks, the oldest first
+func (s *Storage) UserCollections(ctx context.Context, userID int64) ([]*model.Collection, error) {
+	const op = "storage.postgres.UserCollections"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT c.id, c.user_id, c.name, c.slug, c.created_at, c.updated_at, count(ct.song_uuid)
+		FROM collections c
+		LEFT JOIN collection_tracks ct ON ct.collection_id = c.id
+		WHERE c.user_id = $1
+		GROUP BY c.id
+		ORDER BY c.created_at, c.id
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var collections []*model.Collection
+
+	for rows.Next() {
+		var (
+			collection model.Collection
+			slug       sql.NullString
+		)
+
+		if err := rows.Scan(
+			&collection.ID,
+			&collection.UserID,
+			&collection.Name,
+			&slug,
+			&collection.CreatedAt,
+			&collection.UpdatedAt,
+			&collection.TrackCount,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		collection.Slug = slug.String
+
+		collections = append(collections, &collection)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return collections, nil
+}
+
+func (s *Storage) CollectionOwner(ctx context.Context, id string) (int64, error) {
+	const op = "storage.postgres.CollectionOwner"
+
+	var userID int64
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT user_id FROM collections WHERE id = $1
+	`, id).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isInvalidUUID(err) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
+}
+
+func (s *Storage) DeleteCollection(ctx context.Context, id string) error {
+	const op = "storage.postgres.DeleteCollection"
+
+	res, err := s.db.ExecContext(ctx, `
+		DELETE FROM collections WHERE id = $1
+	`, id)
+	if err != nil {
+		if isInvalidUUID(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := collectionChanged(res); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// AddCollectionTrack appends the track to the end of the collection,
+// adding a track the collection already has changes nothing.
+// ErrTrackNotFound is returned if the track isn't stored
+func (s *Storage) AddCollectionTrack(ctx context.Context, id, trackUUID string) error {
+	const op = "storage.postgres.AddCollectionTrack"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	// the collection row stays locked until commit,
+	// so concurrent additions don't get the same position
+	if err := touchCollection(ctx, tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO collection_tracks (collection_id, song_uuid, position)
+		SELECT $1::uuid, $2::uuid, COALESCE(MAX(position), 0) + 1
+		FROM collection_tracks
+		WHERE collection_id = $1
+		ON CONFLICT (collection_id, song_uuid) DO NOTHING
+	`, id, trackUUID)
+	if err != nil {
+		if isInvalidUUID(err) || isForeignKeyViolation(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveCollectionTrack removes the track from the collection,
+// ErrTrackNotFound is returned if the collection doesn't have it
+func (s *Storage) RemoveCollectionTrack(ctx context.Context, id, trackUUID string) error {
+	const op = "storage.postgres.RemoveCollectionTrack"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := touchCollection(ctx, tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM collection_tracks
+		WHERE collection_id = $1 AND song_uuid = $2
+	`, id, trackUUID)
+	if err != nil {
+		if isInvalidUUID(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReorderCollectionTracks sets the order of the collection tracks, ErrInvalidOrder
+// is returned unless trackUUIDs lists every track of the collection exactly once
+func (s *Storage) ReorderCollectionTracks(ctx context.Context, id string, trackUUIDs []string) error {
+	const op = "storage.postgres.ReorderCollectionTracks"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	if err := touchCollection(ctx, tx, id); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE collection_tracks ct
+		SET position = o.position
+		FROM unnest($2::uuid[]) WITH ORDINALITY AS o (song_uuid, position)
+		WHERE ct.collection_id = $1 AND ct.song_uuid = o.song_uuid
+	`, id, pq.Array(trackUUIDs))
+	if err != nil {
+		if isInvalidUUID(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrInvalidOrder)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	var count int64
+	if err := tx.QueryRowContext(ctx, `
+		SELECT count(*) FROM collection_tracks WHERE collection_id = $1
+	`, id).Scan(&count); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	// a repeated track is updated once, so it's caught by the length check
+	if updated != count || updated != int64(len(trackUUIDs)) {
+		return fmt.Errorf("%s: %w", op, storage.ErrInvalidOrder)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetCollectionSlug shares the collection by the slug, an empty slug stops sharing it
+func (s *Storage) SetCollectionSlug(ctx context.Context, id, slug string) error {
+	const op = "storage.postgres.SetCollectionSlug"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE collections
+		SET slug = $2, updated_at = now()
+		WHERE id = $1
+	`, id, sql.NullString{String: slug, Valid: slug != ""})
+	if err != nil {
+		if isInvalidUUID(err) {
+			return fmt.Errorf("%s: %w", op, storage.ErrCollectionNotFound)
+		}
+
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := collectionChanged(res); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// touchCollection updates the collection timestamp and locks its row
+// until the transaction ends
+func touchCollection(ctx context.Context, tx *sql.Tx, id string) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE collections SET updated_at = now() WHERE id = $1
+	`, id)
+	if err != nil {
+		if isInvalidUUID(err) {
+			return storage.ErrCollectionNotFound
+		}
+
+		return err
+	}
+
+	return collectionChanged(res)
+}
+
+// collectionChanged returns ErrCollectionNotFound if the statement changed no collection
+func collectionChanged(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return storage.ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+func scanCollection(row rowScanner) (*model.Collection, error) {
+	var (
+		collection model.Collection
+		slug       sql.NullString
+	)
+
+	err := row.Scan(
+		&collection.ID,
+		&collection.UserID,
+		&collection.Name,
+		&slug,
+		&collection.CreatedAt,
+		&collection.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	collection.Slug = slug.String
+
+	return &collection, nil
+}
@@ -0,0 +1,163 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/storage"
+)
+
+const selectJobs = `
+	SELECT id, user_id, artist, title, lang, status, error, track_uuid, created_at, updated_at
+	FROM import_jobs
+`
+
+// SaveJob stores a new pending job and sets its id and timestamps
+func (s *Storage) SaveJob(ctx context.Context, job *model.Job) error {
+	const op = "storage.postgres.SaveJob"
+
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO import_jobs (user_id, artist, title, lang)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at, updated_at
+	`, nullUserID(job.UserID), job.Artist, job.Title, job.Lang,
+	).Scan(&job.ID, &job.Status, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Job(ctx context.Context, id string) (*model.Job, error) {
+	const op = "storage.postgres.Job"
+
+	job, err := scanJob(s.db.QueryRowContext(ctx, selectJobs+`
+		WHERE id = $1
+	`, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isInvalidUUID(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrJobNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// ClaimJob marks the oldest pending job running and returns it, concurrent
+// workers never claim the same job. ErrNoPendingJobs is returned if there is none
+func (s *Storage) ClaimJob(ctx context.Context) (*model.Job, error) {
+	const op = "storage.postgres.ClaimJob"
+
+	job, err := scanJob(s.db.QueryRowContext(ctx, `
+		UPDATE import_jobs
+		SET status = 'running', updated_at = now()
+		WHERE id = (
+			SELECT id FROM import_jobs
+			WHERE status = 'pending'
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, artist, title, lang, status, error, track_uuid, created_at, updated_at
+	`))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNoPendingJobs)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return job, nil
+}
+
+// FinishJob stores the result of the job returned by ClaimJob, the job is done
+// if errMsg is empty and failed otherwise. ErrJobNotRunning is returned if the job
+// was requeued since it was claimed, the result of its new run isn't overwritten
+func (s *Storage) FinishJob(ctx context.Context, job *model.Job, trackUUID, errMsg string) error {
+	const op = "storage.postgres.FinishJob"
+
+	status := model.JobDone
+	if errMsg != "" {
+		status = model.JobFailed
+	}
+
+	// updated_at of a running job is the time it was claimed at
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE import_jobs
+		SET status = $3, track_uuid = $4, error = $5, updated_at = now()
+		WHERE id = $1 AND status = 'running' AND updated_at = $2
+	`, job.ID, job.UpdatedAt, status, sql.NullString{String: trackUUID, Valid: trackUUID != ""}, errMsg)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%s: %w", op, storage.ErrJobNotRunning)
+	}
+
+	return nil
+}
+
+// RequeueStaleJobs makes jobs running for longer than staleAfter pending again,
+// they were left by a stopped or crashed app
+func (s *Storage) RequeueStaleJobs(ctx context.Context, staleAfter time.Duration) (int64, error) {
+	const op = "storage.postgres.RequeueStaleJobs"
+
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE import_jobs
+		SET status = 'pending', updated_at = now()
+		WHERE status = 'running' AND updated_at < now() - $1 * interval '1 second'
+	`, staleAfter.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n, nil
+}
+
+func scanJob(row rowScanner) (*model.Job, error) {
+	var (
+		job       model.Job
+		userID    sql.NullInt64
+		trackUUID sql.NullString
+	)
+
+	err := row.Scan(
+		&job.ID,
+		&userID,
+		&job.Artist,
+		&job.Title,
+		&job.Lang,
+		&job.Status,
+		&job.Error,
+		&trackUUID,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.UserID = userID.Int64
+	job.TrackUUID = trackUUID.String
+
+	return &job, nil
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/storage"
+)
+
+func TestStorage_FinishJob(t *testing.T) {
+	s := setupStorage(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveJob(ctx, &model.Job{Artist: "Artist", Title: "Title"}))
+
+	first, err := s.ClaimJob(ctx)
+	require.NoError(t, err)
+
+	// the first run outlives staleAfter and the job is claimed again
+	first.UpdatedAt = first.UpdatedAt.Add(-time.Hour)
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE import_jobs SET updated_at = $2 WHERE id = $1
+	`, first.ID, first.UpdatedAt)
+	require.NoError(t, err)
+
+	n, err := s.RequeueStaleJobs(ctx, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	second, err := s.ClaimJob(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	err = s.FinishJob(ctx, first, "", "internal error")
+	assert.ErrorIs(t, err, storage.ErrJobNotRunning)
+
+	require.NoError(t, s.FinishJob(ctx, second, "", "track not found"))
+
+	job, err := s.Job(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.JobFailed, job.Status)
+	assert.Equal(t, "track not found", job.Error)
+
+	// a finished job isn't finished again
+	err = s.FinishJob(ctx, second, "", "internal error")
+	assert.ErrorIs(t, err, storage.ErrJobNotRunning)
+}
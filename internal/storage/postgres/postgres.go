@@ -3,19 +3,61 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 
 	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/normalize"
 	"lyrics-library/internal/storage"
 )
 
+// selectTracks selects songs with their translations aggregated into a json object,
+// queries built on it must end with groupByTrack
+const (
+	selectTracks = `
+		SELECT s.uuid, s.user_id, s.artist, s.title, s.lyrics, s.provider,
+			COALESCE(json_object_agg(t.lang, t.lines) FILTER (WHERE t.lang IS NOT NULL), '{}'),
+			s.created_at, s.updated_at
+		FROM songs s
+		LEFT JOIN translations t ON t.song_uuid = s.uuid
+	`
+	// selectTrackSummaries selects the same columns as selectTracks without lyrics and translations
+	selectTrackSummaries = `
+		SELECT s.uuid, s.user_id, s.artist, s.title, '[]'::jsonb, s.provider,
+			'{}'::json, s.created_at, s.updated_at
+		FROM songs s
+	`
+	groupByTrack = `
+		GROUP BY s.uuid
+	`
+)
+
 type Storage struct {
 	db *sql.DB
 }
 
+// pgInvalidTextRepresentation is returned by postgres for malformed uuids,
+// pgInvalidDatetimeFormat for malformed timestamps and pgForeignKeyViolation
+// for references to missing rows
+const (
+	pgInvalidTextRepresentation = "22P02"
+	pgInvalidDatetimeFormat     = "22007"
+	pgForeignKeyViolation       = "23503"
+)
+
+// headlineOptions wrap found words in <mark> tags and keep up to two short fragments
+const headlineOptions = `StartSel=<mark>, StopSel=</mark>, MinWords=5, MaxWords=20, ` +
+	`MaxFragments=2, FragmentDelimiter=" ... "`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
 func New(dbURL string) (*Storage, error) {
 	const op = "storage.postgres.New"
 
@@ -31,6 +73,8 @@ func New(dbURL string) (*Storage, error) {
 	return &Storage{db: db}, nil
 }
 
+// SaveTrack inserts the track with its translations and fills its generated fields,
+// ErrTrackExists is returned if a track with the same normalized artist and title is stored
 func (s *Storage) SaveTrack(ctx context.Context, track *model.Track) error {
 	const op = "storage.postgres.Save"
 
@@ -40,17 +84,53 @@ func (s *Storage) SaveTrack(ctx context.Context, track *model.Track) error {
 	}
 	defer tx.Rollback()
 
-	_, err = tx.ExecContext(ctx, `
-		INSERT INTO songs (artist, title, lyrics, translation)
-		VALUES ($1, $2, $3, $4)
-	`, track.Artist, track.Title, pq.Array(track.Lyrics), pq.Array(track.Translation))
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO songs (user_id, artist, title, lyrics, provider, artist_key, title_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (artist_key, title_key) DO NOTHING
+		RETURNING uuid, created_at, updated_at
+	`, nullUserID(track.UserID), track.Artist, track.Title, stanzasValue(track.Lyrics), track.Provider,
+		normalize.Name(track.Artist), normalize.Name(track.Title),
+	).Scan(&track.UUID, &track.CreatedAt, &track.UpdatedAt)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%s: %w", op, storage.ErrTrackExists)
+		}
+
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	for lang, stanzas := range track.Translations {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO translations (song_uuid, lang, lines)
+			VALUES ($1, $2, $3)
+		`, track.UUID, lang, stanzasValue(stanzas))
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
 	return tx.Commit()
 }
 
+// SaveTranslation stores one more translation of the track,
+// a translation saved concurrently for the same language is kept
+func (s *Storage) SaveTranslation(ctx context.Context, uuid, lang string, stanzas []model.Stanza) error {
+	const op = "storage.postgres.SaveTranslation"
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO translations (song_uuid, lang, lines)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (song_uuid, lang) DO NOTHING
+	`, uuid, lang, stanzasValue(stanzas))
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Track looks the track up by normalized artist and title
 func (s *Storage) Track(ctx context.Context, artist, title string) (*model.Track, error) {
 	const op = "storage.postgres.TrackInfo"
 
@@ -60,14 +140,11 @@ func (s *Storage) Track(ctx context.Context, artist, title string) (*model.Track
 	}
 	defer tx.Rollback()
 
-	row := tx.QueryRowContext(ctx, `
-		SELECT artist, title, lyrics, translation FROM songs 
-		WHERE artist ILIKE $1 AND title ILIKE $2
-	`, artist, title)
-
-	var lyrics, translation []string
+	row := tx.QueryRowContext(ctx, selectTracks+`
+		WHERE s.artist_key = $1 AND s.title_key = $2
+	`+groupByTrack, normalize.Name(artist), normalize.Name(title))
 
-	err = row.Scan(&artist, &title, pq.Array(&lyrics), pq.Array(&translation))
+	track, err := scanTrack(row)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, storage.ErrTrackNotFound
@@ -80,91 +157,608 @@ func (s *Storage) Track(ctx context.Context, artist, title string) (*model.Track
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &model.Track{
-		Artist:      artist,
-		Title:       title,
-		Lyrics:      lyrics,
-		Translation: translation,
-	}, nil
+	return track, nil
+}
+
+// SimilarTracks returns up to limit tracks whose artist or title is similar
+// to the given ones by trigrams, the most similar first
+func (s *Storage) SimilarTracks(
+	ctx context.Context,
+	artist, title string,
+	limit int,
+) ([]*model.Suggestion, error) {
+	const op = "storage.postgres.SimilarTracks"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT uuid, artist, title,
+			(similarity(artist_key, $1) + similarity(title_key, $2)) / 2 AS score
+		FROM songs
+		WHERE artist_key % $1 OR title_key % $2
+		ORDER BY score DESC, uuid
+		LIMIT $3
+	`, normalize.Name(artist), normalize.Name(title), limit)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var suggestions []*model.Suggestion
+	for rows.Next() {
+		var suggestion model.Suggestion
+		if err := rows.Scan(
+			&suggestion.UUID,
+			&suggestion.Artist,
+			&suggestion.Title,
+			&suggestion.Score,
+		); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		suggestions = append(suggestions, &suggestion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return suggestions, nil
 }
 
-func (s *Storage) TracksByArtist(ctx context.Context, artist string) ([]*model.Track, error) {
+func (s *Storage) TrackByUUID(ctx context.Context, uuid string) (*model.Track, error) {
+	const op = "storage.postgres.TrackByUUID"
+
+	row := s.db.QueryRowContext(ctx, selectTracks+`
+		WHERE s.uuid = $1
+	`+groupByTrack, uuid)
+
+	track, err := scanTrack(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || isInvalidUUID(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return track, nil
+}
+
+// TracksByArtist returns a page of artist's tracks matched by normalized name
+// ordered by page.SortBy with uuid as a tie breaker,
+// ErrArtistTracksNotFound is returned only if the first page is empty
+func (s *Storage) TracksByArtist(
+	ctx context.Context,
+	artist string,
+	page model.PageQuery,
+) (*model.TrackPage, error) {
 	const op = "storage.postgres.TracksByArtist"
 
+	sortExpr, keyType := "s.title", "text"
+	if page.SortBy == model.SortByCreatedAt {
+		sortExpr, keyType = "s.created_at", "timestamptz"
+	}
+
+	dir, cmp := "ASC", ">"
+	if page.Desc {
+		dir, cmp = "DESC", "<"
+	}
+
+	query := selectTracks
+	if page.Summary {
+		query = selectTrackSummaries
+	}
+
+	query += `
+		WHERE s.artist_key = $1
+	`
+	args := []any{normalize.Name(artist)}
+
+	if page.After != nil {
+		query += fmt.Sprintf(`
+			AND (%s, s.uuid) %s ($2::%s, $3::uuid)
+		`, sortExpr, cmp, keyType)
+		args = append(args, page.After.Key, page.After.UUID)
+	}
+
+	// one extra row tells if there is a next page
+	query += groupByTrack + fmt.Sprintf(`
+		ORDER BY %s %s, s.uuid %s
+		LIMIT %d
+	`, sortExpr, dir, dir, page.Limit+1)
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
 
-	rows, err := tx.QueryContext(ctx, `
-		SELECT artist, title, lyrics, translation
-		FROM songs WHERE artist ILIKE $1
-	`, artist)
+	rows, err := tx.QueryContext(ctx, query, args...)
 	if err != nil {
+		if isInvalidUUID(err) || isInvalidDatetime(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidCursor)
+		}
+
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var tracks []*model.Track
+	tracks, err := scanTracks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if len(tracks) == 0 && page.After == nil {
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrArtistTracksNotFound)
+	}
+
+	result := &model.TrackPage{Tracks: tracks}
+
+	if len(tracks) > page.Limit {
+		result.Tracks = tracks[:page.Limit]
+
+		last := result.Tracks[page.Limit-1]
+
+		key := last.Title
+		if page.SortBy == model.SortByCreatedAt {
+			key = last.CreatedAt.Format(time.RFC3339Nano)
+		}
+
+		result.Next = &model.Cursor{
+			SortBy: page.SortBy,
+			Desc:   page.Desc,
+			Key:    key,
+			UUID:   last.UUID,
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Storage) TracksByUser(ctx context.Context, userID int64) ([]*model.Track, error) {
+	const op = "storage.postgres.TracksByUser"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, selectTracks+`
+		WHERE s.user_id = $1
+	`+groupByTrack, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tracks, err := scanTracks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tracks, nil
+}
+
+// ExportTracks calls fn for every track matching the filter ordered by artist and title.
+// Rows are read one by one, so the library is never loaded into memory at once.
+// An error returned by fn stops the export and is returned
+func (s *Storage) ExportTracks(
+	ctx context.Context,
+	filter model.ExportFilter,
+	fn func(track *model.Track) error,
+) error {
+	const op = "storage.postgres.ExportTracks"
+
+	query := selectTracks + `
+		WHERE TRUE
+	`
+
+	var args []any
+
+	if filter.Artist != "" {
+		args = append(args, normalize.Name(filter.Artist))
+		query += fmt.Sprintf(`
+			AND s.artist_key = $%d
+		`, len(args))
+	}
+
+	if filter.UserID != 0 {
+		args = append(args, filter.UserID)
+		query += fmt.Sprintf(`
+			AND s.user_id = $%d
+		`, len(args))
+	}
+
+	query += groupByTrack + `
+		ORDER BY s.artist_key, s.title_key, s.uuid
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		track, err := scanTrack(rows)
+		if err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		if err := fn(track); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SearchTracks finds tracks whose lyrics or translation into lang match the query,
+// the best ranked tracks come first
+func (s *Storage) SearchTracks(
+	ctx context.Context,
+	query, lang string,
+	limit int,
+) ([]*model.SearchResult, error) {
+	const op = "storage.postgres.SearchTracks"
+
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		WITH matches AS (
+			SELECT s.uuid, ts_rank(s.search_vector, q) AS rank, '' AS lang
+			FROM songs s, websearch_to_tsquery('simple', $1) q
+			WHERE s.search_vector @@ q
+			UNION ALL
+			SELECT t.song_uuid, ts_rank(t.search_vector, q), t.lang
+			FROM translations t, websearch_to_tsquery(ts_config($2), $1) q
+			WHERE t.lang = $2 AND t.search_vector @@ q
+		), best AS (
+			SELECT DISTINCT ON (uuid) uuid, rank, lang
+			FROM matches
+			ORDER BY uuid, rank DESC
+		), ranked AS (
+			SELECT uuid, rank, lang
+			FROM best
+			ORDER BY rank DESC, uuid
+			LIMIT $3
+		)
+		SELECT r.uuid, r.rank, r.lang,
+			CASE WHEN r.lang = ''
+				THEN ts_headline('simple', lyrics_text(s.lyrics),
+					websearch_to_tsquery('simple', $1), $4)
+				ELSE ts_headline(ts_config($2), lyrics_text(t.lines),
+					websearch_to_tsquery(ts_config($2), $1), $4)
+			END
+		FROM ranked r
+		JOIN songs s ON s.uuid = r.uuid
+		LEFT JOIN translations t ON t.song_uuid = r.uuid AND t.lang = r.lang
+		ORDER BY r.rank DESC, r.uuid
+	`, query, lang, limit, headlineOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	var (
-		title       string
-		lyrics      []string
-		translation []string
+		results []*model.SearchResult
+		uuids   []string
 	)
+
 	for rows.Next() {
-		err := rows.Scan(&artist, &title, pq.Array(&lyrics), pq.Array(&translation))
-		if err != nil {
+		var (
+			uuid   string
+			result model.SearchResult
+		)
+
+		if err := rows.Scan(&uuid, &result.Rank, &result.MatchLang, &result.Snippet); err != nil {
+			rows.Close()
+
 			return nil, fmt.Errorf("%s: %w", op, err)
 		}
 
-		tracks = append(tracks, &model.Track{
-			Artist:      artist,
-			Title:       title,
-			Lyrics:      lyrics,
-			Translation: translation,
-		})
+		results = append(results, &result)
+		uuids = append(uuids, uuid)
 	}
+	rows.Close()
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	rows, err = tx.QueryContext(ctx, selectTracks+`
+		WHERE s.uuid = ANY($1)
+	`+groupByTrack, pq.Array(uuids))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	tracks, err := scanTracks(rows)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	if len(tracks) == 0 {
-		return nil, fmt.Errorf("%s: %w", op, storage.ErrArtistTracksNotFound)
+	byUUID := make(map[string]*model.Track, len(tracks))
+	for _, track := range tracks {
+		byUUID[track.UUID] = track
 	}
 
-	return tracks, nil
+	found := results[:0]
+	for i, result := range results {
+		// the track could be deleted between the queries
+		if track, ok := byUUID[uuids[i]]; ok {
+			result.Track = track
+			found = append(found, result)
+		}
+	}
+
+	return found, nil
 }
 
-func (s *Storage) DeleteTrack(ctx context.Context, uuid string) error {
-	const op = "storage.postgres.DeleteTrack"
+// UpdateTrack replaces lyrics and the translation into lang of the track saved by the user,
+// nil slices keep stored values. New lyrics drop stored translations since their lines
+// no longer match. ErrNotTrackOwner is returned if the track belongs to another user
+// or has no owner
+func (s *Storage) UpdateTrack(
+	ctx context.Context,
+	uuid string,
+	userID int64,
+	lyrics []model.Stanza,
+	lang string,
+	translation []model.Stanza,
+) (*model.Track, error) {
+	const op = "storage.postgres.UpdateTrack"
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 	defer tx.Rollback()
 
-	res, err := tx.ExecContext(ctx, `DELETE FROM songs WHERE uuid = $1`, uuid)
+	res, err := tx.ExecContext(ctx, `
+		UPDATE songs SET
+			lyrics = COALESCE($3, lyrics),
+			updated_at = now()
+		WHERE uuid = $1 AND user_id = $2
+	`, uuid, userID, stanzasValue(lyrics))
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		if isInvalidUUID(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	rowsAffected, err := res.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("%s: %w", op, storage.ErrInvalidUUID)
+		exists, err := trackExists(ctx, tx, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if exists {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrNotTrackOwner)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, storage.ErrTrackNotFound)
 	}
 
-	return tx.Commit()
+	if lyrics != nil {
+		_, err := tx.ExecContext(ctx, `
+			DELETE FROM translations WHERE song_uuid = $1
+		`, uuid)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if translation != nil {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO translations (song_uuid, lang, lines)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (song_uuid, lang) DO UPDATE SET lines = EXCLUDED.lines
+		`, uuid, lang, stanzasValue(translation))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	row := tx.QueryRowContext(ctx, selectTracks+`
+		WHERE s.uuid = $1
+	`+groupByTrack, uuid)
+
+	track, err := scanTrack(row)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return track, nil
+}
+
+// DeleteTrack deletes the track saved by the user, any track if admin is set, and returns
+// its uuid, artist and title so cache entries of the track can be evicted.
+// ErrNotTrackOwner is returned if the track belongs to another user or, unless admin
+// is set, has no owner
+func (s *Storage) DeleteTrack(ctx context.Context, uuid string, userID int64, admin bool) (*model.Track, error) {
+	const op = "storage.postgres.DeleteTrack"
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer tx.Rollback()
+
+	var track model.Track
+	err = tx.QueryRowContext(ctx, `
+		DELETE FROM songs WHERE uuid = $1 AND (user_id = $2 OR $3)
+		RETURNING uuid, artist, title
+	`, uuid, userID, admin).Scan(&track.UUID, &track.Artist, &track.Title)
+	if err != nil {
+		if isInvalidUUID(err) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidUUID)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			exists, err := trackExists(ctx, tx, uuid)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+
+			if exists {
+				return nil, fmt.Errorf("%s: %w", op, storage.ErrNotTrackOwner)
+			}
+
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrInvalidUUID)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &track, nil
+}
+
+// trackExists tells a track of another user from a missing one
+// after a write matched no rows
+func trackExists(ctx context.Context, tx *sql.Tx, uuid string) (bool, error) {
+	var exists bool
+
+	err := tx.QueryRowContext(ctx, `
+		SELECT EXISTS (SELECT 1 FROM songs WHERE uuid = $1)
+	`, uuid).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func scanTracks(rows *sql.Rows) ([]*model.Track, error) {
+	defer rows.Close()
+
+	var tracks []*model.Track
+
+	for rows.Next() {
+		track, err := scanTrack(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tracks = append(tracks, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tracks, nil
+}
+
+func scanTrack(row rowScanner) (*model.Track, error) {
+	var (
+		track        model.Track
+		userID       sql.NullInt64
+		lyrics       []byte
+		translations []byte
+	)
+
+	err := row.Scan(
+		&track.UUID,
+		&userID,
+		&track.Artist,
+		&track.Title,
+		&lyrics,
+		&track.Provider,
+		&translations,
+		&track.CreatedAt,
+		&track.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(lyrics, &track.Lyrics); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(translations, &track.Translations); err != nil {
+		return nil, err
+	}
+
+	track.UserID = userID.Int64
+
+	return &track, nil
+}
+
+func isInvalidUUID(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == pgInvalidTextRepresentation
+}
+
+func isInvalidDatetime(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == pgInvalidDatetimeFormat
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation
+}
+
+// stanzasValue encodes stanzas for a jsonb column, nil stanzas are passed as NULL
+type stanzasValue []model.Stanza
+
+func (v stanzasValue) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal([]model.Stanza(v))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
+
+// nullUserID stores tracks saved by anonymous callers without an owner
+func nullUserID(userID int64) sql.NullInt64 {
+	return sql.NullInt64{Int64: userID, Valid: userID != 0}
 }
 
 func (s *Storage) Ping(ctx context.Context) error {
@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+)
+
+// UserRoles returns roles granted to the user in alphabetical order
+func (s *Storage) UserRoles(ctx context.Context, userID int64) ([]string, error) {
+	const op = "storage.postgres.UserRoles"
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var roles []string
+
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"lyrics-library/internal/storage"
+)
+
+// unlockScript deletes the lock only if it's still held with the token,
+// a lock expired and taken by another replica is left alone
+var unlockScript = redis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// Lock takes the lock shared by all replicas using the same redis. It waits
+// up to Lock.Wait for the lock to be released, a lock of a crashed replica
+// expires after Lock.TTL
+func (s *Storage) Lock(ctx context.Context, name string) (func(ctx context.Context) error, error) {
+	const op = "storage.redis.Lock"
+
+	key := s.lockKey(name)
+
+	token, err := lockToken()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	timeout := time.NewTimer(s.lock.Wait)
+	defer timeout.Stop()
+
+	retry := time.NewTicker(s.lock.RetryInterval)
+	defer retry.Stop()
+
+	for {
+		ok, err := s.db.SetNX(ctx, key, token, s.lock.TTL).Result()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if ok {
+			return func(ctx context.Context) error {
+				if err := unlockScript.Run(ctx, s.db, []string{key}, token).Err(); err != nil {
+					return fmt.Errorf("%s: %w", op, err)
+				}
+
+				return nil
+			}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%s: %w", op, ctx.Err())
+		case <-timeout.C:
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrLockNotAcquired)
+		case <-retry.C:
+		}
+	}
+}
+
+func lockToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func (s *Storage) lockKey(name string) string {
+	return fmt.Sprintf("%s:lock:%s", s.version, name)
+}
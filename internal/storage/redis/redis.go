@@ -5,23 +5,32 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 
+	"lyrics-library/internal/config"
 	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/normalize"
 	"lyrics-library/internal/storage"
 )
 
 type Storage struct {
-	db *redis.Client
+	db      *redis.Client
+	version string
+	ttl     config.RedisTTLConfig
+	lock    config.RedisLockConfig
 }
 
-func New(redisURL, password string) (*Storage, error) {
+// New connects to redis at addr. Keys are prefixed with cfg.KeyVersion so entries
+// written in an older format are never decoded, they just expire
+func New(addr string, cfg config.RedisConfig) (*Storage, error) {
 	const op = "storage.redis.New"
 
 	db := redis.NewClient(&redis.Options{
-		Addr:     redisURL,
-		Password: password,
+		Addr:     addr,
+		Password: cfg.Password,
 		DB:       0,
 	})
 
@@ -30,22 +39,33 @@ func New(redisURL, password string) (*Storage, error) {
 	}
 
 	return &Storage{
-		db: db,
+		db:      db,
+		version: cfg.KeyVersion,
+		ttl:     cfg.TTL,
+		lock:    cfg.Lock,
 	}, nil
 }
 
 func (s *Storage) SaveTrack(ctx context.Context, track *model.Track) error {
 	const op = "storage.redis.SaveTrack"
 
-	key := generateTrackKey(track.Artist, track.Title)
+	key := s.trackKey(track.Artist, track.Title)
 
 	data, err := json.Marshal(track)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	err = s.db.Set(ctx, key, data, 0).Err()
-	if err != nil {
+	ttl := s.expiration(s.ttl.Track)
+
+	pipe := s.db.TxPipeline()
+
+	pipe.Set(ctx, key, data, ttl)
+	if track.UUID != "" {
+		pipe.Set(ctx, s.trackUUIDKey(track.UUID), data, ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
@@ -55,7 +75,7 @@ func (s *Storage) SaveTrack(ctx context.Context, track *model.Track) error {
 func (s *Storage) Track(ctx context.Context, artist, title string) (*model.Track, error) {
 	const op = "storage.redis.GetTrack"
 
-	key := generateTrackKey(artist, title)
+	key := s.trackKey(artist, title)
 
 	data, err := s.db.Get(ctx, key).Bytes()
 	if err != nil {
@@ -74,43 +94,192 @@ func (s *Storage) Track(ctx context.Context, artist, title string) (*model.Track
 	return &track, err
 }
 
-func (s *Storage) SaveArtistTracks(ctx context.Context, artist string, tracks []*model.Track) error {
+func (s *Storage) TrackByUUID(ctx context.Context, uuid string) (*model.Track, error) {
+	const op = "storage.redis.TrackByUUID"
+
+	data, err := s.db.Get(ctx, s.trackUUIDKey(uuid)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, fmt.Errorf("%s: %w", op, storage.ErrTrackNotCached)
+		}
+
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var track model.Track
+	if err := json.Unmarshal(data, &track); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &track, nil
+}
+
+// InvalidateTrack evicts the track cached by artist and title and by uuid
+func (s *Storage) InvalidateTrack(ctx context.Context, track *model.Track) error {
+	const op = "storage.redis.InvalidateTrack"
+
+	keys := []string{s.trackKey(track.Artist, track.Title)}
+	if track.UUID != "" {
+		keys = append(keys, s.trackUUIDKey(track.UUID))
+	}
+
+	if err := s.db.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// InvalidateArtistTracks evicts every cached page of artist's tracks
+func (s *Storage) InvalidateArtistTracks(ctx context.Context, artist string) error {
+	const op = "storage.redis.InvalidateArtistTracks"
+
+	keys, err := s.artistTracksPageKeys(ctx, artist)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err := s.db.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// artistTracksPageKeys returns the keys of every cached page of artist's tracks
+// with the key of the set tracking them
+func (s *Storage) artistTracksPageKeys(ctx context.Context, artist string) ([]string, error) {
+	pagesKey := s.artistTracksPagesKey(artist)
+
+	keys, err := s.db.SMembers(ctx, pagesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(keys, pagesKey), nil
+}
+
+// SaveArtistTracks caches one page of artist's tracks, page identifies the page query.
+// Page keys are tracked in a set so all pages of the artist can be evicted at once
+func (s *Storage) SaveArtistTracks(
+	ctx context.Context,
+	artist, page string,
+	tracks *model.TrackPage,
+) error {
 	const op = "storage.redis.SaveArtistTracks"
 
-	key := generateArtistTracksKey(artist)
+	key := s.artistTracksKey(artist, page)
+	pagesKey := s.artistTracksPagesKey(artist)
 
 	data, err := json.Marshal(tracks)
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	if err := s.db.Set(ctx, key, data, 0).Err(); err != nil {
+	pipe := s.db.TxPipeline()
+
+	pipe.Set(ctx, key, data, s.expiration(s.ttl.ArtistTracks))
+	pipe.SAdd(ctx, pagesKey, key)
+	// the set outlives every page it tracks
+	if s.ttl.ArtistTracks > 0 {
+		pipe.Expire(ctx, pagesKey, s.ttl.ArtistTracks+s.maxJitter(s.ttl.ArtistTracks))
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
 	return nil
 }
 
-func (s *Storage) ArtistTracks(ctx context.Context, artist string) ([]*model.Track, error) {
+func (s *Storage) ArtistTracks(ctx context.Context, artist, page string) (*model.TrackPage, error) {
 	const op = "storage.redis.GetArtistTracks"
 
-	key := generateArtistTracksKey(artist)
+	key := s.artistTracksKey(artist, page)
 
 	data, err := s.db.Get(ctx, key).Bytes()
 	if err != nil {
-		if errors.Is(err, storage.ErrArtistTracksNotCached) {
+		if errors.Is(err, redis.Nil) {
 			return nil, fmt.Errorf("%s: %w", op, storage.ErrArtistTracksNotCached)
 		}
 
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var tracks []*model.Track
+	var tracks model.TrackPage
 	if err := json.Unmarshal(data, &tracks); err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return tracks, nil
+	return &tracks, nil
+}
+
+// SaveMiss remembers that nothing was found for artist and title, title is
+// ignored for storage.MissArtistTracks. Misses expire after their own TTL
+func (s *Storage) SaveMiss(ctx context.Context, miss storage.Miss, artist, title string) error {
+	const op = "storage.redis.SaveMiss"
+
+	if err := s.db.Set(ctx, s.missKey(miss, artist, title), 1, s.expiration(s.ttl.NotFound)).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// Missed reports whether a miss for artist and title is cached
+func (s *Storage) Missed(ctx context.Context, miss storage.Miss, artist, title string) (bool, error) {
+	const op = "storage.redis.Missed"
+
+	n, err := s.db.Exists(ctx, s.missKey(miss, artist, title)).Result()
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return n > 0, nil
+}
+
+// InvalidateMisses evicts every miss a track saved for artist and title makes stale
+func (s *Storage) InvalidateMisses(ctx context.Context, artist, title string) error {
+	const op = "storage.redis.InvalidateMisses"
+
+	keys := []string{
+		s.missKey(storage.MissTrack, artist, title),
+		s.missKey(storage.MissLyrics, artist, title),
+		s.missKey(storage.MissArtistTracks, artist, ""),
+	}
+
+	if err := s.db.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SaveToken caches the owner of the validated token by the token hash, no jitter
+// is added to ttl so the entry never outlives the token
+func (s *Storage) SaveToken(ctx context.Context, hash string, userID int64, ttl time.Duration) error {
+	const op = "storage.redis.SaveToken"
+
+	if err := s.db.Set(ctx, s.tokenKey(hash), userID, ttl).Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) Token(ctx context.Context, hash string) (int64, error) {
+	const op = "storage.redis.Token"
+
+	userID, err := s.db.Get(ctx, s.tokenKey(hash)).Int64()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, fmt.Errorf("%s: %w", op, storage.ErrTokenNotCached)
+		}
+
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return userID, nil
 }
 
 func (s *Storage) Close(ctx context.Context) error {
@@ -125,10 +294,47 @@ func (s *Storage) Ping(ctx context.Context) error {
 	return s.db.Ping(ctx).Err()
 }
 
-func generateArtistTracksKey(artist string) string {
-	return fmt.Sprintf("artist_tracks:%s", artist)
+// expiration spreads ttl by a random jitter so entries cached together
+// don't expire at once, zero ttl keeps entries forever
+func (s *Storage) expiration(ttl time.Duration) time.Duration {
+	jitter := s.maxJitter(ttl)
+	if ttl <= 0 || jitter <= 0 {
+		return ttl
+	}
+
+	return ttl + rand.N(jitter+1)
+}
+
+func (s *Storage) maxJitter(ttl time.Duration) time.Duration {
+	return time.Duration(float64(ttl) * s.ttl.Jitter)
+}
+
+// trackKey uses the same normalized artist and title as postgres lookup,
+// so every spelling matching the stored track shares one cache entry
+func (s *Storage) trackKey(artist, title string) string {
+	return fmt.Sprintf("%s:track:%s:%s", s.version, normalize.Name(artist), normalize.Name(title))
+}
+
+func (s *Storage) trackUUIDKey(uuid string) string {
+	return fmt.Sprintf("%s:track_uuid:%s", s.version, uuid)
+}
+
+func (s *Storage) artistTracksKey(artist, page string) string {
+	return fmt.Sprintf("%s:artist_tracks:%s:%s", s.version, normalize.Name(artist), page)
+}
+
+func (s *Storage) artistTracksPagesKey(artist string) string {
+	return fmt.Sprintf("%s:artist_tracks_pages:%s", s.version, normalize.Name(artist))
+}
+
+func (s *Storage) missKey(miss storage.Miss, artist, title string) string {
+	if miss == storage.MissArtistTracks {
+		return fmt.Sprintf("%s:miss:%s:%s", s.version, miss, normalize.Name(artist))
+	}
+
+	return fmt.Sprintf("%s:miss:%s:%s:%s", s.version, miss, normalize.Name(artist), normalize.Name(title))
 }
 
-func generateTrackKey(artist, title string) string {
-	return fmt.Sprintf("track:%s:%s", artist, title)
+func (s *Storage) tokenKey(hash string) string {
+	return fmt.Sprintf("%s:token:%s", s.version, hash)
 }
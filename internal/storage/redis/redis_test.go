@@ -0,0 +1,44 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/config"
+	"lyrics-library/internal/storage"
+)
+
+func TestStorage_Keys(t *testing.T) {
+	t.Parallel()
+
+	s := &Storage{version: "v2"}
+
+	assert.Equal(t, "v2:track:eminem:lose yourself", s.trackKey("Eminem", "Lose Yourself"))
+	assert.Equal(t, s.trackKey("Eminem", "Lose Yourself"), s.trackKey("eminem", "lose yourself!"))
+	assert.Equal(t, "v2:track_uuid:e434dc13", s.trackUUIDKey("e434dc13"))
+	assert.Equal(t, "v2:artist_tracks:acdc:title:false:20:false:", s.artistTracksKey("AC/DC", "title:false:20:false:"))
+	assert.Equal(t, "v2:artist_tracks_pages:beyonce", s.artistTracksPagesKey("Beyoncé"))
+	assert.Equal(t, "v2:miss:lyrics:eminem:stan", s.missKey(storage.MissLyrics, "EMINEM", "Stan (feat. Dido)"))
+	assert.Equal(t, "v2:miss:artist_tracks:eminem", s.missKey(storage.MissArtistTracks, "Eminem", "ignored"))
+	assert.Equal(t, "v2:token:9f86d081", s.tokenKey("9f86d081"))
+}
+
+func TestStorage_Expiration(t *testing.T) {
+	t.Parallel()
+
+	s := &Storage{ttl: config.RedisTTLConfig{Jitter: 0.1}}
+
+	for range 100 {
+		ttl := s.expiration(time.Hour)
+
+		assert.GreaterOrEqual(t, ttl, time.Hour)
+		assert.LessOrEqual(t, ttl, time.Hour+6*time.Minute)
+	}
+
+	assert.Zero(t, s.expiration(0))
+
+	s.ttl.Jitter = 0
+	assert.Equal(t, time.Hour, s.expiration(time.Hour))
+}
@@ -4,8 +4,30 @@ import "errors"
 
 var (
 	ErrTrackNotFound         = errors.New("track not found")
+	ErrTrackExists           = errors.New("track already exists")
 	ErrArtistTracksNotFound  = errors.New("artist's tracks not found")
 	ErrInvalidUUID           = errors.New("invalid uuid")
+	ErrNotTrackOwner         = errors.New("track belongs to another user")
+	ErrInvalidCursor         = errors.New("invalid cursor")
 	ErrTrackNotCached        = errors.New("track not cached")
 	ErrArtistTracksNotCached = errors.New("artist's track not cached")
+	ErrLockNotAcquired       = errors.New("lock not acquired")
+	ErrJobNotFound           = errors.New("job not found")
+	ErrNoPendingJobs         = errors.New("no pending jobs")
+	ErrJobNotRunning         = errors.New("job is not running")
+	ErrCollectionNotFound    = errors.New("collection not found")
+	ErrCollectionExists      = errors.New("collection already exists")
+	ErrInvalidOrder          = errors.New("invalid collection order")
+	ErrAPIKeyNotFound        = errors.New("api key not found")
+	ErrAPIKeyExists          = errors.New("api key already exists")
+	ErrTokenNotCached        = errors.New("token not cached")
+)
+
+// Miss is a kind of lookup whose "not found" result is cached
+type Miss string
+
+const (
+	MissTrack        Miss = "track"
+	MissLyrics       Miss = "lyrics"
+	MissArtistTracks Miss = "artist_tracks"
 )
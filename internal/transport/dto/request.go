@@ -3,9 +3,54 @@ package dto
 type CreateRequest struct {
 	Artist string `json:"artist" binding:"required" example:"Juice WRLD"`
 	Title  string `json:"title" binding:"required" example:"Lucid Dreams"`
+	Lang   string `json:"lang" binding:"omitempty,max=10,bcp47_language_tag" example:"ru"`
+}
+
+// UpdateRequest lines are split into stanzas by empty lines and [Section] markers
+type UpdateRequest struct {
+	Lyrics      []string `json:"lyrics" example:"I still see your shadows in my room"`
+	Lang        string   `json:"lang" binding:"omitempty,max=10,bcp47_language_tag" example:"ru"`
+	Translation []string `json:"translation" example:"Я все еще вижу твои тени в моей комнате"`
+}
+
+// BatchQuery sets the translation language of tracks without their own lang
+// and whether the tracks are imported in background
+type BatchQuery struct {
+	Lang  string `form:"lang" binding:"omitempty,max=10,bcp47_language_tag" example:"uk"`
+	Async bool   `form:"async"`
+}
+
+// FieldsSummary leaves lyrics and translations out of track listings
+const FieldsSummary = "summary"
+
+// PageRequest selects a page of a track listing
+type PageRequest struct {
+	Limit int `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
+	// Sort is title or created_at, a leading '-' sorts in descending order
+	Sort string `form:"sort" binding:"omitempty,oneof=title -title created_at -created_at" example:"-created_at"`
+	// Cursor is next_cursor of the previous page
+	Cursor string `form:"cursor"`
+	Fields string `form:"fields" binding:"omitempty,oneof=summary full" example:"summary"`
 }
 
 type CredentialsRequest struct {
 	Email    string `json:"email" binding:"required" validate:"email" example:"test@test.com"`
 	Password string `json:"password" binding:"required" example:"matveyisgoat123"`
 }
+
+type CollectionRequest struct {
+	Name string `json:"name" binding:"required,max=255" example:"Favorites"`
+}
+
+type CollectionTrackRequest struct {
+	UUID string `json:"uuid" binding:"required" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+}
+
+// ReorderRequest lists every track of the collection in the new order
+type ReorderRequest struct {
+	Tracks []string `json:"tracks" binding:"required" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+}
+
+type APIKeyRequest struct {
+	Name string `json:"name" binding:"required,max=255" example:"ci"`
+}
@@ -1,36 +1,454 @@
 package dto
 
-import "lyrics-library/internal/domain/model"
+import (
+	"time"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/cursor"
+	"lyrics-library/internal/lib/lyrics"
+)
 
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// TrackResponse Lyrics and Translation are the flattened Stanzas,
+// kept for clients reading plain lines
 type TrackResponse struct {
-	Artist      string   `json:"artist" example:"Lucid Dreams"`
-	Title       string   `json:"title" example:"Juice WRLD"`
-	Lyrics      []string `json:"lyrics" example:"I still see your shadows in my room..."`
-	Translation []string `json:"translation" example:"Я все еще вижу твои тени в моей комнате..."`
+	UUID        string            `json:"uuid" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	Artist      string            `json:"artist" example:"Lucid Dreams"`
+	Title       string            `json:"title" example:"Juice WRLD"`
+	Lang        string            `json:"lang" example:"ru"`
+	Provider    string            `json:"provider,omitempty" example:"lyricsovh"`
+	Lyrics      []string          `json:"lyrics" example:"I still see your shadows in my room..."`
+	Translation []string          `json:"translation" example:"Я все еще вижу твои тени в моей комнате..."`
+	Stanzas     []*StanzaResponse `json:"stanzas,omitempty"`
+	CreatedAt   time.Time         `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt   time.Time         `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+// StanzaResponse Times are the start times of lines in seconds, set for time-synced lyrics only
+type StanzaResponse struct {
+	Label       string    `json:"label,omitempty" example:"Chorus"`
+	Lines       []string  `json:"lines" example:"I still see your shadows in my room"`
+	Translation []string  `json:"translation" example:"Я все еще вижу твои тени в моей комнате"`
+	Times       []float64 `json:"times,omitempty" example:"12.5"`
+}
+
+// TrackLineResponse is the line sung at a playback position, positions are in seconds.
+// Line is null before the first line
+type TrackLineResponse struct {
+	UUID     string             `json:"uuid" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	Lang     string             `json:"lang" example:"ru"`
+	Position float64            `json:"position" example:"83.5"`
+	Line     *TimedLineResponse `json:"line"`
+}
+
+// TimedLineResponse End is the start of the next line, omitted for the last line
+type TimedLineResponse struct {
+	Index       int     `json:"index" example:"12"`
+	Label       string  `json:"label,omitempty" example:"Chorus"`
+	Original    string  `json:"original" example:"I still see your shadows in my room"`
+	Translation string  `json:"translation" example:"Я все еще вижу твои тени в моей комнате"`
+	Start       float64 `json:"start" example:"82.3"`
+	End         float64 `json:"end,omitempty" example:"86.05"`
+}
+
+// ModeLines is the response mode pairing every lyrics line with its translation
+const ModeLines = "lines"
+
+type LinePair struct {
+	Original    string `json:"original" example:"I still see your shadows in my room"`
+	Translation string `json:"translation" example:"Я все еще вижу твои тени в моей комнате"`
+}
+
+type TrackLinesResponse struct {
+	UUID      string     `json:"uuid" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	Artist    string     `json:"artist" example:"Lucid Dreams"`
+	Title     string     `json:"title" example:"Juice WRLD"`
+	Lang      string     `json:"lang" example:"ru"`
+	Provider  string     `json:"provider,omitempty" example:"lyricsovh"`
+	Lines     []LinePair `json:"lines"`
+	CreatedAt time.Time  `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt time.Time  `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+type SearchResultResponse struct {
+	Track   *TrackResponse `json:"track"`
+	Rank    float64        `json:"rank" example:"0.0759"`
+	Snippet string         `json:"snippet" example:"I still see your <mark>shadows</mark> in my room"`
+	// MatchLang is the translation language the query was found in, empty for the original lyrics
+	MatchLang string `json:"match_lang,omitempty" example:"ru"`
+}
+
+type TracksPageResponse struct {
+	Tracks []*TrackResponse `json:"tracks"`
+	// NextCursor is passed as cursor to get the next page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty" example:"eyJzIjoidGl0bGUiLCJrIjoiTHVjaWQgRHJlYW1zIn0"`
+}
+
+// TrackSummaryResponse is a track listed without lyrics and translation
+type TrackSummaryResponse struct {
+	UUID      string    `json:"uuid" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	Artist    string    `json:"artist" example:"Lucid Dreams"`
+	Title     string    `json:"title" example:"Juice WRLD"`
+	Provider  string    `json:"provider,omitempty" example:"lyricsovh"`
+	CreatedAt time.Time `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+type SuggestionResponse struct {
+	UUID   string  `json:"uuid" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	Artist string  `json:"artist" example:"Juice WRLD"`
+	Title  string  `json:"title" example:"Lucid Dreams"`
+	Score  float64 `json:"score" example:"0.72"`
+}
+
+// TrackNotFoundResponse lists stored tracks similar to the requested one
+type TrackNotFoundResponse struct {
+	Error       string                `json:"error" example:"track not found"`
+	Suggestions []*SuggestionResponse `json:"suggestions"`
+}
+
+// JobResponse is a background track import, TrackUUID is set once it's done
+// and Error once it's failed
+type JobResponse struct {
+	ID        string    `json:"id" example:"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"`
+	Status    string    `json:"status" example:"done" enums:"pending,running,done,failed"`
+	Artist    string    `json:"artist" example:"Juice WRLD"`
+	Title     string    `json:"title" example:"Lucid Dreams"`
+	Lang      string    `json:"lang,omitempty" example:"ru"`
+	Error     string    `json:"error,omitempty" example:"track not found"`
+	TrackUUID string    `json:"track_uuid,omitempty" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	CreatedAt time.Time `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+// BatchItemResponse is the outcome of a bulk import item, TrackUUID is set
+// for saved and existing tracks, JobID for queued ones
+type BatchItemResponse struct {
+	Artist    string `json:"artist" example:"Juice WRLD"`
+	Title     string `json:"title" example:"Lucid Dreams"`
+	Lang      string `json:"lang,omitempty" example:"ru"`
+	Status    string `json:"status" example:"saved" enums:"saved,exists,not_found,translation_failed,failed,queued"`
+	TrackUUID string `json:"track_uuid,omitempty" example:"e434dc13-ada5-4bde-b695-d97014dadebc"`
+	JobID     string `json:"job_id,omitempty" example:"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"`
+}
+
+type BatchResponse struct {
+	Results []*BatchItemResponse `json:"results"`
+}
+
+// CollectionResponse is a collection with its tracks in order, Slug is set while it's shared
+type CollectionResponse struct {
+	ID        string                  `json:"id" example:"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"`
+	Name      string                  `json:"name" example:"Favorites"`
+	Slug      string                  `json:"slug,omitempty" example:"q2Vh0Zt1aLw3nXcR"`
+	Tracks    []*TrackSummaryResponse `json:"tracks"`
+	CreatedAt time.Time               `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt time.Time               `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+// CollectionSummaryResponse is a collection listed without its tracks
+type CollectionSummaryResponse struct {
+	ID         string    `json:"id" example:"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"`
+	Name       string    `json:"name" example:"Favorites"`
+	Slug       string    `json:"slug,omitempty" example:"q2Vh0Zt1aLw3nXcR"`
+	TrackCount int       `json:"track_count" example:"12"`
+	CreatedAt  time.Time `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	UpdatedAt  time.Time `json:"updated_at" example:"2025-05-01T12:00:00Z"`
+}
+
+// ShareResponse holds the slug the collection is read at /collections/shared/{slug}
+type ShareResponse struct {
+	Slug string `json:"slug" example:"q2Vh0Zt1aLw3nXcR"`
+}
+
+// APIKeyResponse describes a key without the key itself, Prefix is its start
+// to recognize the key by
+type APIKeyResponse struct {
+	ID         string     `json:"id" example:"9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59"`
+	Name       string     `json:"name" example:"ci"`
+	Prefix     string     `json:"prefix" example:"lk_q2Vh0Zt1"`
+	CreatedAt  time.Time  `json:"created_at" example:"2025-05-01T12:00:00Z"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" example:"2025-05-02T08:30:00Z"`
+}
+
+// CreatedAPIKeyResponse holds the new key, it's shown only once
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" example:"lk_q2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw"`
 }
 
 type LoginResponse struct {
 	Token string `json:"token"`
 }
 
-func ToTrackResponse(t *model.Track) *TrackResponse {
+// ToTrackResponse converts the track with its translation into lang
+func ToTrackResponse(t *model.Track, lang string) *TrackResponse {
 	return &TrackResponse{
+		UUID:        t.UUID,
 		Artist:      t.Artist,
 		Title:       t.Title,
-		Lyrics:      t.Lyrics,
-		Translation: t.Translation,
+		Lang:        lang,
+		Provider:    t.Provider,
+		Lyrics:      lyrics.Flatten(t.Lyrics),
+		Translation: lyrics.Flatten(t.Translations[lang]),
+		Stanzas:     toStanzaResponses(t.Lyrics, t.Translations[lang]),
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// toStanzaResponses pairs stanzas with the translated stanzas at the same position
+func toStanzaResponses(stanzas, translation []model.Stanza) []*StanzaResponse {
+	responses := make([]*StanzaResponse, len(stanzas))
+
+	for i, stanza := range stanzas {
+		responses[i] = &StanzaResponse{
+			Label: stanza.Label,
+			Lines: stanza.Lines,
+			Times: seconds(stanza.Times),
+		}
+
+		if i < len(translation) {
+			responses[i].Translation = translation[i].Lines
+		}
+	}
+
+	return responses
+}
+
+// seconds converts durations to seconds, nil stays nil
+func seconds(times []time.Duration) []float64 {
+	if times == nil {
+		return nil
+	}
+
+	result := make([]float64, len(times))
+	for i, t := range times {
+		result[i] = t.Seconds()
 	}
+
+	return result
 }
 
-func TracksToTrackResponses(tracks []*model.Track) []*TrackResponse {
+func TracksToTrackResponses(tracks []*model.Track, lang string) []*TrackResponse {
 	responses := make([]*TrackResponse, len(tracks))
 
 	for i, track := range tracks {
-		responses[i] = ToTrackResponse(track)
+		responses[i] = ToTrackResponse(track, lang)
+	}
+
+	return responses
+}
+
+// ToTrackLinesResponse pairs lyrics lines with translation lines,
+// a missing line on either side is left empty
+func ToTrackLinesResponse(t *TrackResponse) *TrackLinesResponse {
+	lines := make([]LinePair, max(len(t.Lyrics), len(t.Translation)))
+
+	for i := range lines {
+		if i < len(t.Lyrics) {
+			lines[i].Original = t.Lyrics[i]
+		}
+
+		if i < len(t.Translation) {
+			lines[i].Translation = t.Translation[i]
+		}
+	}
+
+	return &TrackLinesResponse{
+		UUID:      t.UUID,
+		Artist:    t.Artist,
+		Title:     t.Title,
+		Lang:      t.Lang,
+		Provider:  t.Provider,
+		Lines:     lines,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+// TrackView returns the track in the requested response mode
+func TrackView(t *TrackResponse, mode string) any {
+	if mode == ModeLines {
+		return ToTrackLinesResponse(t)
+	}
+
+	return t
+}
+
+// TracksView returns the tracks in the requested response mode
+func TracksView(tracks []*TrackResponse, mode string) any {
+	if mode != ModeLines {
+		return tracks
+	}
+
+	views := make([]*TrackLinesResponse, len(tracks))
+	for i, track := range tracks {
+		views[i] = ToTrackLinesResponse(track)
+	}
+
+	return views
+}
+
+// ToSearchResultResponses converts search results with the tracks translated into lang
+func ToSearchResultResponses(results []*model.SearchResult, lang string) []*SearchResultResponse {
+	responses := make([]*SearchResultResponse, len(results))
+
+	for i, result := range results {
+		responses[i] = &SearchResultResponse{
+			Track:     ToTrackResponse(result.Track, lang),
+			Rank:      result.Rank,
+			Snippet:   result.Snippet,
+			MatchLang: result.MatchLang,
+		}
+	}
+
+	return responses
+}
+
+func ToTracksPageResponse(page *model.TrackPage, lang string) *TracksPageResponse {
+	return &TracksPageResponse{
+		Tracks:     TracksToTrackResponses(page.Tracks, lang),
+		NextCursor: cursor.Encode(page.Next),
+	}
+}
+
+type tracksPageView struct {
+	Tracks     any    `json:"tracks"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TracksPageView returns the page with tracks in the requested fields and response mode
+func TracksPageView(page *TracksPageResponse, fields, mode string) any {
+	if fields != FieldsSummary {
+		return tracksPageView{
+			Tracks:     TracksView(page.Tracks, mode),
+			NextCursor: page.NextCursor,
+		}
+	}
+
+	summaries := make([]*TrackSummaryResponse, len(page.Tracks))
+	for i, t := range page.Tracks {
+		summaries[i] = &TrackSummaryResponse{
+			UUID:      t.UUID,
+			Artist:    t.Artist,
+			Title:     t.Title,
+			Provider:  t.Provider,
+			CreatedAt: t.CreatedAt,
+			UpdatedAt: t.UpdatedAt,
+		}
+	}
+
+	return tracksPageView{
+		Tracks:     summaries,
+		NextCursor: page.NextCursor,
+	}
+}
+
+func ToSuggestionResponses(suggestions []*model.Suggestion) []*SuggestionResponse {
+	responses := make([]*SuggestionResponse, len(suggestions))
+
+	for i, suggestion := range suggestions {
+		responses[i] = &SuggestionResponse{
+			UUID:   suggestion.UUID,
+			Artist: suggestion.Artist,
+			Title:  suggestion.Title,
+			Score:  suggestion.Score,
+		}
+	}
+
+	return responses
+}
+
+func ToJobResponse(job *model.Job) *JobResponse {
+	return &JobResponse{
+		ID:        job.ID,
+		Status:    job.Status,
+		Artist:    job.Artist,
+		Title:     job.Title,
+		Lang:      job.Lang,
+		Error:     job.Error,
+		TrackUUID: job.TrackUUID,
+		CreatedAt: job.CreatedAt,
+		UpdatedAt: job.UpdatedAt,
+	}
+}
+
+func ToBatchResponse(results []model.BatchResult) *BatchResponse {
+	responses := make([]*BatchItemResponse, len(results))
+
+	for i, result := range results {
+		responses[i] = &BatchItemResponse{
+			Artist:    result.Item.Artist,
+			Title:     result.Item.Title,
+			Lang:      result.Item.Lang,
+			Status:    result.Status,
+			TrackUUID: result.TrackUUID,
+			JobID:     result.JobID,
+		}
+	}
+
+	return &BatchResponse{Results: responses}
+}
+
+func ToCollectionResponse(collection *model.Collection) *CollectionResponse {
+	tracks := make([]*TrackSummaryResponse, len(collection.Tracks))
+	for i, t := range collection.Tracks {
+		tracks[i] = &TrackSummaryResponse{
+			UUID:      t.UUID,
+			Artist:    t.Artist,
+			Title:     t.Title,
+			Provider:  t.Provider,
+			CreatedAt: t.CreatedAt,
+			UpdatedAt: t.UpdatedAt,
+		}
+	}
+
+	return &CollectionResponse{
+		ID:        collection.ID,
+		Name:      collection.Name,
+		Slug:      collection.Slug,
+		Tracks:    tracks,
+		CreatedAt: collection.CreatedAt,
+		UpdatedAt: collection.UpdatedAt,
+	}
+}
+
+func ToCollectionSummaryResponses(collections []*model.Collection) []*CollectionSummaryResponse {
+	responses := make([]*CollectionSummaryResponse, len(collections))
+
+	for i, collection := range collections {
+		responses[i] = &CollectionSummaryResponse{
+			ID:         collection.ID,
+			Name:       collection.Name,
+			Slug:       collection.Slug,
+			TrackCount: collection.TrackCount,
+			CreatedAt:  collection.CreatedAt,
+			UpdatedAt:  collection.UpdatedAt,
+		}
+	}
+
+	return responses
+}
+
+func ToAPIKeyResponse(key *model.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedAt:  key.CreatedAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
+
+func ToAPIKeyResponses(keys []*model.APIKey) []*APIKeyResponse {
+	responses := make([]*APIKeyResponse, len(keys))
+
+	for i, key := range keys {
+		responses[i] = ToAPIKeyResponse(key)
 	}
 
 	return responses
@@ -0,0 +1,86 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	apikeyService "lyrics-library/internal/service/apikey"
+	"lyrics-library/internal/transport/dto"
+)
+
+type KeyCreator interface {
+	Create(ctx context.Context, name string, userID int64) (*model.APIKey, string, error)
+}
+
+// @Summary Create an API key
+// @Description Issues a long-lived key of the authenticated caller, names are unique per user.
+// @Description The key is sent as Authorization: Bearer <key> and is shown only in this response.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param input body dto.APIKeyRequest true "Key name"
+// @Success 201 {object} dto.CreatedAPIKeyResponse "Created key"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "API key already exists"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /auth/api-keys [post]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	keyCreator KeyCreator,
+) gin.HandlerFunc {
+	const op = "handler.apikey.create.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		var req dto.APIKeyRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Error("request body is empty")
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "request body is empty"})
+				return
+			}
+			log.Error("failed to decode request body", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request"})
+			return
+		}
+
+		key, secret, err := keyCreator.Create(ctx, req.Name, userID)
+		if err != nil {
+			if errors.Is(err, apikeyService.ErrKeyExists) {
+				c.JSON(http.StatusConflict, dto.ErrorResponse{Error: "api key already exists"})
+				return
+			}
+
+			log.Error("failed to create api key", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.CreatedAPIKeyResponse{
+			APIKeyResponse: *dto.ToAPIKeyResponse(key),
+			Key:            secret,
+		})
+	}
+}
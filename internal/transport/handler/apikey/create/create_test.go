@@ -0,0 +1,140 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+	apikeyService "lyrics-library/internal/service/apikey"
+)
+
+type MockKeyCreator struct {
+	mock.Mock
+}
+
+func (m *MockKeyCreator) Create(ctx context.Context, name string, userID int64) (*model.APIKey, string, error) {
+	args := m.Called(ctx, name, userID)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*model.APIKey), args.String(1), args.Error(2)
+}
+
+func TestCreateHandler(t *testing.T) {
+	const secret = "lk_q2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw3nXcRq2Vh0Zt1aLw"
+
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		anonymous      bool
+		mockSetup      func(*MockKeyCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "key created",
+			requestBody: `{"name": "ci"}`,
+			mockSetup: func(m *MockKeyCreator) {
+				m.On("Create", mock.Anything, "ci", int64(1)).
+					Return(&model.APIKey{
+						ID:        "9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59",
+						UserID:    1,
+						Name:      "ci",
+						Prefix:    "lk_q2Vh0Zt1",
+						CreatedAt: createdAt,
+					}, secret, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody: `{"id":"9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59","name":"ci","prefix":"lk_q2Vh0Zt1",` +
+				`"created_at":"2025-05-01T12:00:00Z","key":"` + secret + `"}`,
+		},
+		{
+			name:           "empty request body",
+			requestBody:    "",
+			mockSetup:      func(m *MockKeyCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"request body is empty"}`,
+		},
+		{
+			name:           "missing name",
+			requestBody:    `{}`,
+			mockSetup:      func(m *MockKeyCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request"}`,
+		},
+		{
+			name:           "unauthorized",
+			requestBody:    `{"name": "ci"}`,
+			anonymous:      true,
+			mockSetup:      func(m *MockKeyCreator) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:        "name already used",
+			requestBody: `{"name": "ci"}`,
+			mockSetup: func(m *MockKeyCreator) {
+				m.On("Create", mock.Anything, "ci", int64(1)).
+					Return(nil, "", apikeyService.ErrKeyExists)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"api key already exists"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{"name": "ci"}`,
+			mockSetup: func(m *MockKeyCreator) {
+				m.On("Create", mock.Anything, "ci", int64(1)).
+					Return(nil, "", errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := new(MockKeyCreator)
+			tt.mockSetup(mockCreator)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			router.POST("/auth/api-keys", New(context.Background(), log, mockCreator))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/auth/api-keys", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockCreator.AssertExpectations(t)
+		})
+	}
+}
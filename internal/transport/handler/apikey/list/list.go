@@ -0,0 +1,56 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type KeyLister interface {
+	List(ctx context.Context, userID int64) ([]*model.APIKey, error)
+}
+
+// @Summary List caller's API keys
+// @Description Returns keys of the authenticated caller without the keys themselves, the oldest first
+// @Tags auth
+// @Produce json
+// @Success 200 {array} dto.APIKeyResponse "Caller's keys"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /auth/api-keys [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	keyLister KeyLister,
+) gin.HandlerFunc {
+	const op = "handler.apikey.list.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		keys, err := keyLister.List(ctx, userID)
+		if err != nil {
+			log.Error("failed to list api keys", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToAPIKeyResponses(keys))
+	}
+}
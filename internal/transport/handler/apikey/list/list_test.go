@@ -0,0 +1,125 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+)
+
+type MockKeyLister struct {
+	mock.Mock
+}
+
+func (m *MockKeyLister) List(ctx context.Context, userID int64) ([]*model.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.APIKey), args.Error(1)
+}
+
+func TestListHandler(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	usedAt := time.Date(2025, 5, 2, 8, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockKeyLister)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "caller's keys",
+			mockSetup: func(m *MockKeyLister) {
+				m.On("List", mock.Anything, int64(1)).
+					Return([]*model.APIKey{
+						{
+							ID:        "9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59",
+							UserID:    1,
+							Name:      "ci",
+							Prefix:    "lk_q2Vh0Zt1",
+							CreatedAt: createdAt,
+						},
+						{
+							ID:         "4c8d2e6f-1a3b-4c5d-8e9f-0a1b2c3d4e5f",
+							UserID:     1,
+							Name:       "phone",
+							Prefix:     "lk_aLw3nXcR",
+							CreatedAt:  createdAt,
+							LastUsedAt: &usedAt,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `[{"id":"9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59","name":"ci","prefix":"lk_q2Vh0Zt1","created_at":"2025-05-01T12:00:00Z"},` +
+				`{"id":"4c8d2e6f-1a3b-4c5d-8e9f-0a1b2c3d4e5f","name":"phone","prefix":"lk_aLw3nXcR","created_at":"2025-05-01T12:00:00Z","last_used_at":"2025-05-02T08:30:00Z"}]`,
+		},
+		{
+			name: "no keys",
+			mockSetup: func(m *MockKeyLister) {
+				m.On("List", mock.Anything, int64(1)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "unauthorized",
+			anonymous:      true,
+			mockSetup:      func(m *MockKeyLister) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockKeyLister) {
+				m.On("List", mock.Anything, int64(1)).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockLister := new(MockKeyLister)
+			tt.mockSetup(mockLister)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			router.GET("/auth/api-keys", New(context.Background(), log, mockLister))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/auth/api-keys", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockLister.AssertExpectations(t)
+		})
+	}
+}
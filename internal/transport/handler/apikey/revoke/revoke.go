@@ -0,0 +1,62 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	apikeyService "lyrics-library/internal/service/apikey"
+	"lyrics-library/internal/transport/dto"
+)
+
+type KeyRevoker interface {
+	Revoke(ctx context.Context, id string, userID int64) error
+}
+
+// @Summary Revoke an API key
+// @Description Deletes the caller's key, requests with it are rejected from then on.
+// @Tags auth
+// @Param id path string true "API key ID" example(9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59)
+// @Success 204 "API key revoked"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "API key not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /auth/api-keys/{id} [delete]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	keyRevoker KeyRevoker,
+) gin.HandlerFunc {
+	const op = "handler.apikey.revoke.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized revoke attempt")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		if err := keyRevoker.Revoke(ctx, c.Param("id"), userID); err != nil {
+			if errors.Is(err, apikeyService.ErrKeyNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "api key not found"})
+				return
+			}
+
+			log.Error("failed to revoke api key", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,100 @@
+package revoke
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	apikeyService "lyrics-library/internal/service/apikey"
+)
+
+type MockKeyRevoker struct {
+	mock.Mock
+}
+
+func (m *MockKeyRevoker) Revoke(ctx context.Context, id string, userID int64) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func TestRevokeHandler(t *testing.T) {
+	const id = "9b2e7c1a-4f3d-4e8b-a6c5-1d0f2e3b4a59"
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockKeyRevoker)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "key revoked",
+			mockSetup: func(m *MockKeyRevoker) {
+				m.On("Revoke", mock.Anything, id, int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+		{
+			name:           "anonymous caller",
+			anonymous:      true,
+			mockSetup:      func(m *MockKeyRevoker) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "api key not found",
+			mockSetup: func(m *MockKeyRevoker) {
+				m.On("Revoke", mock.Anything, id, int64(1)).Return(apikeyService.ErrKeyNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"api key not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockKeyRevoker) {
+				m.On("Revoke", mock.Anything, id, int64(1)).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRevoker := new(MockKeyRevoker)
+			tt.mockSetup(mockRevoker)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.DELETE("/auth/api-keys/:id", New(context.Background(), log, mockRevoker))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/auth/api-keys/"+id, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockRevoker.AssertExpectations(t)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 
+	"lyrics-library/internal/config"
 	"lyrics-library/internal/lib/logger/sl"
 	authService "lyrics-library/internal/service/auth"
 	"lyrics-library/internal/transport/dto"
@@ -25,7 +26,8 @@ type UserLogin interface {
 }
 
 // @Summary Login a user
-// @Description Login a user with email and password
+// @Description Login a user with email and password. The token is returned and set as the jwt cookie,
+// @Description clients without cookies send it as Authorization: Bearer <token>
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -39,6 +41,7 @@ func New(
 	ctx context.Context,
 	log *slog.Logger,
 	userLogin UserLogin,
+	cookie config.CookieConfig,
 ) gin.HandlerFunc {
 	const op = "handler.auth.login.New"
 
@@ -83,13 +86,14 @@ func New(
 			return
 		}
 
+		c.SetSameSite(cookie.SameSiteMode())
 		c.SetCookie(
 			"jwt",
 			token,
 			int(jwtMaxAge.Seconds()),
 			"/",
-			"localhost",
-			false,
+			cookie.Domain,
+			cookie.Secure,
 			true,
 		)
 
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"lyrics-library/internal/config"
 	authService "lyrics-library/internal/service/auth"
 	"lyrics-library/internal/transport/dto"
 )
@@ -118,6 +119,7 @@ func TestLoginHandler(t *testing.T) {
 				context.Background(),
 				slog.Default(),
 				mockLogin,
+				config.CookieConfig{Domain: "localhost", SameSite: "lax"},
 			)
 
 			req, _ := http.NewRequest(http.MethodPost, "/login", strings.NewReader(tt.requestBody))
@@ -138,3 +140,44 @@ func TestLoginHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestLoginCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		cookie   config.CookieConfig
+		expected string
+	}{
+		{
+			name:     "local",
+			cookie:   config.CookieConfig{Domain: "localhost", SameSite: "lax"},
+			expected: "jwt=jwt.token.here; Path=/; Domain=localhost; Max-Age=3600; HttpOnly; SameSite=Lax",
+		},
+		{
+			name:     "cross-site",
+			cookie:   config.CookieConfig{Domain: "lyrics.example.com", Secure: true, SameSite: "none"},
+			expected: "jwt=jwt.token.here; Path=/; Domain=lyrics.example.com; Max-Age=3600; HttpOnly; Secure; SameSite=None",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockLogin := new(mockUserLogin)
+			mockLogin.On("Login", mock.Anything, mock.Anything).Return("jwt.token.here", nil)
+
+			router := gin.New()
+			router.POST("/login", New(context.Background(), slog.Default(), mockLogin, tt.cookie))
+
+			req := httptest.NewRequest(http.MethodPost, "/login",
+				strings.NewReader(`{"email": "test@example.com", "password": "validpassword123"}`))
+			req.Header.Set("Content-Type", "application/json")
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.expected, w.Header().Get("Set-Cookie"))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package add
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackAdder interface {
+	AddTrack(ctx context.Context, id, trackUUID string, userID int64) error
+}
+
+// @Summary Add a track to a collection
+// @Description Appends a stored track to the end of the caller's collection.
+// @Description Adding a track the collection already has changes nothing.
+// @Tags collection
+// @Accept json
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Param input body dto.CollectionTrackRequest true "Track UUID"
+// @Success 204 "Track added"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection or track not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id}/tracks [post]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackAdder TrackAdder,
+) gin.HandlerFunc {
+	const op = "handler.collection.add.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		var req dto.CollectionTrackRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Error("request body is empty")
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "request body is empty"})
+				return
+			}
+			log.Error("failed to decode request body", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request"})
+			return
+		}
+
+		if err := trackAdder.AddTrack(ctx, c.Param("id"), req.UUID, userID); err != nil {
+			switch {
+			case errors.Is(err, collectionService.ErrCollectionNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+			case errors.Is(err, collectionService.ErrTrackNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track not found"})
+			default:
+				log.Error("failed to add track to collection", sl.Err(err))
+
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,136 @@
+package add
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockTrackAdder struct {
+	mock.Mock
+}
+
+func (m *MockTrackAdder) AddTrack(ctx context.Context, id, trackUUID string, userID int64) error {
+	args := m.Called(ctx, id, trackUUID, userID)
+	return args.Error(0)
+}
+
+func TestAddHandler(t *testing.T) {
+	const (
+		id        = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+		trackUUID = "e434dc13-ada5-4bde-b695-d97014dadebc"
+	)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		anonymous      bool
+		mockSetup      func(*MockTrackAdder)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "track added",
+			requestBody: `{"uuid": "e434dc13-ada5-4bde-b695-d97014dadebc"}`,
+			mockSetup: func(m *MockTrackAdder) {
+				m.On("AddTrack", mock.Anything, id, trackUUID, int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+		{
+			name:           "empty request body",
+			requestBody:    "",
+			mockSetup:      func(m *MockTrackAdder) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"request body is empty"}`,
+		},
+		{
+			name:           "missing uuid",
+			requestBody:    `{}`,
+			mockSetup:      func(m *MockTrackAdder) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request"}`,
+		},
+		{
+			name:           "anonymous caller",
+			requestBody:    `{"uuid": "e434dc13-ada5-4bde-b695-d97014dadebc"}`,
+			anonymous:      true,
+			mockSetup:      func(m *MockTrackAdder) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:        "collection not found",
+			requestBody: `{"uuid": "e434dc13-ada5-4bde-b695-d97014dadebc"}`,
+			mockSetup: func(m *MockTrackAdder) {
+				m.On("AddTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name:        "track not found",
+			requestBody: `{"uuid": "e434dc13-ada5-4bde-b695-d97014dadebc"}`,
+			mockSetup: func(m *MockTrackAdder) {
+				m.On("AddTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(collectionService.ErrTrackNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track not found"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{"uuid": "e434dc13-ada5-4bde-b695-d97014dadebc"}`,
+			mockSetup: func(m *MockTrackAdder) {
+				m.On("AddTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockAdder := new(MockTrackAdder)
+			tt.mockSetup(mockAdder)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.POST("/collections/:id/tracks", New(context.Background(), log, mockAdder))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/collections/"+id+"/tracks", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockAdder.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionCreator interface {
+	Create(ctx context.Context, name string, userID int64) (*model.Collection, error)
+}
+
+// @Summary Create a collection
+// @Description Creates an empty collection of the authenticated caller, names are unique per user.
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param input body dto.CollectionRequest true "Collection name"
+// @Success 201 {object} dto.CollectionResponse "Created collection"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 409 {object} dto.ErrorResponse "Collection already exists"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections [post]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionCreator CollectionCreator,
+) gin.HandlerFunc {
+	const op = "handler.collection.create.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		var req dto.CollectionRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Error("request body is empty")
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "request body is empty"})
+				return
+			}
+			log.Error("failed to decode request body", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request"})
+			return
+		}
+
+		collection, err := collectionCreator.Create(ctx, req.Name, userID)
+		if err != nil {
+			if errors.Is(err, collectionService.ErrCollectionExists) {
+				c.JSON(http.StatusConflict, dto.ErrorResponse{Error: "collection already exists"})
+				return
+			}
+
+			log.Error("failed to create collection", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.ToCollectionResponse(collection))
+	}
+}
@@ -0,0 +1,137 @@
+package create
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockCollectionCreator struct {
+	mock.Mock
+}
+
+func (m *MockCollectionCreator) Create(ctx context.Context, name string, userID int64) (*model.Collection, error) {
+	args := m.Called(ctx, name, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func TestCreateHandler(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		anonymous      bool
+		mockSetup      func(*MockCollectionCreator)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "collection created",
+			requestBody: `{"name": "Favorites"}`,
+			mockSetup: func(m *MockCollectionCreator) {
+				m.On("Create", mock.Anything, "Favorites", int64(1)).
+					Return(&model.Collection{
+						ID:        "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d",
+						UserID:    1,
+						Name:      "Favorites",
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"id":"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d","name":"Favorites","tracks":[],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name:           "empty request body",
+			requestBody:    "",
+			mockSetup:      func(m *MockCollectionCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"request body is empty"}`,
+		},
+		{
+			name:           "missing name",
+			requestBody:    `{}`,
+			mockSetup:      func(m *MockCollectionCreator) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request"}`,
+		},
+		{
+			name:           "unauthorized",
+			requestBody:    `{"name": "Favorites"}`,
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionCreator) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:        "name already used",
+			requestBody: `{"name": "Favorites"}`,
+			mockSetup: func(m *MockCollectionCreator) {
+				m.On("Create", mock.Anything, "Favorites", int64(1)).
+					Return(nil, collectionService.ErrCollectionExists)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedBody:   `{"error":"collection already exists"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{"name": "Favorites"}`,
+			mockSetup: func(m *MockCollectionCreator) {
+				m.On("Create", mock.Anything, "Favorites", int64(1)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockCreator := new(MockCollectionCreator)
+			tt.mockSetup(mockCreator)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			router.POST("/collections", New(context.Background(), log, mockCreator))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/collections", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockCreator.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionDeleter interface {
+	Delete(ctx context.Context, id string, userID int64) error
+}
+
+// @Summary Delete a collection
+// @Description Deletes the caller's collection, its tracks stay stored.
+// @Tags collection
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Success 204 "Collection deleted"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id} [delete]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionDeleter CollectionDeleter,
+) gin.HandlerFunc {
+	const op = "handler.collection.delete.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized delete attempt")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		if err := collectionDeleter.Delete(ctx, c.Param("id"), userID); err != nil {
+			if errors.Is(err, collectionService.ErrCollectionNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+				return
+			}
+
+			log.Error("failed to delete collection", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,100 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockCollectionDeleter struct {
+	mock.Mock
+}
+
+func (m *MockCollectionDeleter) Delete(ctx context.Context, id string, userID int64) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+func TestDeleteHandler(t *testing.T) {
+	const id = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockCollectionDeleter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful deletion",
+			mockSetup: func(m *MockCollectionDeleter) {
+				m.On("Delete", mock.Anything, id, int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+		{
+			name:           "anonymous caller",
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionDeleter) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "collection not found",
+			mockSetup: func(m *MockCollectionDeleter) {
+				m.On("Delete", mock.Anything, id, int64(1)).Return(collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockCollectionDeleter) {
+				m.On("Delete", mock.Anything, id, int64(1)).Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockDeleter := new(MockCollectionDeleter)
+			tt.mockSetup(mockDeleter)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.DELETE("/collections/:id", New(context.Background(), log, mockDeleter))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/collections/"+id, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockDeleter.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionProvider interface {
+	Collection(ctx context.Context, id string, userID int64) (*model.Collection, error)
+}
+
+// @Summary Get a collection
+// @Description Returns the caller's collection with its tracks in order.
+// @Description Collections of other users aren't found.
+// @Tags collection
+// @Produce json
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Success 200 {object} dto.CollectionResponse "Collection"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id} [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionProvider CollectionProvider,
+) gin.HandlerFunc {
+	const op = "handler.collection.get.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		collection, err := collectionProvider.Collection(ctx, c.Param("id"), userID)
+		if err != nil {
+			if errors.Is(err, collectionService.ErrCollectionNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+				return
+			}
+
+			log.Error("failed to get collection", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCollectionResponse(collection))
+	}
+}
@@ -0,0 +1,128 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockCollectionProvider struct {
+	mock.Mock
+}
+
+func (m *MockCollectionProvider) Collection(ctx context.Context, id string, userID int64) (*model.Collection, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func TestGetHandler(t *testing.T) {
+	const id = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockCollectionProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "collection with tracks",
+			mockSetup: func(m *MockCollectionProvider) {
+				m.On("Collection", mock.Anything, id, int64(1)).
+					Return(&model.Collection{
+						ID:     id,
+						UserID: 1,
+						Name:   "Favorites",
+						Tracks: []*model.Track{{
+							UUID:      "e434dc13-ada5-4bde-b695-d97014dadebc",
+							Artist:    "Juice WRLD",
+							Title:     "Lucid Dreams",
+							Provider:  "lyricsovh",
+							CreatedAt: createdAt,
+							UpdatedAt: createdAt,
+						}},
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"id":"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d","name":"Favorites",` +
+				`"tracks":[{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","provider":"lyricsovh","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}],` +
+				`"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name:           "unauthorized",
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionProvider) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "collection not found",
+			mockSetup: func(m *MockCollectionProvider) {
+				m.On("Collection", mock.Anything, id, int64(1)).
+					Return(nil, collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockCollectionProvider) {
+				m.On("Collection", mock.Anything, id, int64(1)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockCollectionProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			router.GET("/collections/:id", New(context.Background(), log, mockProvider))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/collections/"+id, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
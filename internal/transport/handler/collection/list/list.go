@@ -0,0 +1,56 @@
+package list
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionLister interface {
+	List(ctx context.Context, userID int64) ([]*model.Collection, error)
+}
+
+// @Summary List caller's collections
+// @Description Returns collections of the authenticated caller without their tracks, the oldest first
+// @Tags collection
+// @Produce json
+// @Success 200 {array} dto.CollectionSummaryResponse "Caller's collections"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionLister CollectionLister,
+) gin.HandlerFunc {
+	const op = "handler.collection.list.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		collections, err := collectionLister.List(ctx, userID)
+		if err != nil {
+			log.Error("failed to list collections", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCollectionSummaryResponses(collections))
+	}
+}
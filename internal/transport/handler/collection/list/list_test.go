@@ -0,0 +1,115 @@
+package list
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+)
+
+type MockCollectionLister struct {
+	mock.Mock
+}
+
+func (m *MockCollectionLister) List(ctx context.Context, userID int64) ([]*model.Collection, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Collection), args.Error(1)
+}
+
+func TestListHandler(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockCollectionLister)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "caller's collections",
+			mockSetup: func(m *MockCollectionLister) {
+				m.On("List", mock.Anything, int64(1)).
+					Return([]*model.Collection{{
+						ID:         "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d",
+						UserID:     1,
+						Name:       "Favorites",
+						Slug:       "q2Vh0Zt1aLw3nXcR",
+						TrackCount: 2,
+						CreatedAt:  createdAt,
+						UpdatedAt:  createdAt,
+					}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"id":"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d","name":"Favorites","slug":"q2Vh0Zt1aLw3nXcR","track_count":2,"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}]`,
+		},
+		{
+			name: "no collections",
+			mockSetup: func(m *MockCollectionLister) {
+				m.On("List", mock.Anything, int64(1)).Return(nil, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "unauthorized",
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionLister) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockCollectionLister) {
+				m.On("List", mock.Anything, int64(1)).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockLister := new(MockCollectionLister)
+			tt.mockSetup(mockLister)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			router.GET("/collections", New(context.Background(), log, mockLister))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/collections", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockLister.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackRemover interface {
+	RemoveTrack(ctx context.Context, id, trackUUID string, userID int64) error
+}
+
+// @Summary Remove a track from a collection
+// @Description Removes the track from the caller's collection, the track stays stored.
+// @Tags collection
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Param uuid path string true "Track UUID" example(e434dc13-ada5-4bde-b695-d97014dadebc)
+// @Success 204 "Track removed"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection or track not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id}/tracks/{uuid} [delete]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackRemover TrackRemover,
+) gin.HandlerFunc {
+	const op = "handler.collection.remove.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		if err := trackRemover.RemoveTrack(ctx, c.Param("id"), c.Param("uuid"), userID); err != nil {
+			switch {
+			case errors.Is(err, collectionService.ErrCollectionNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+			case errors.Is(err, collectionService.ErrTrackNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track not found"})
+			default:
+				log.Error("failed to remove track from collection", sl.Err(err))
+
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			}
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
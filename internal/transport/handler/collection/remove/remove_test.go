@@ -0,0 +1,114 @@
+package remove
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockTrackRemover struct {
+	mock.Mock
+}
+
+func (m *MockTrackRemover) RemoveTrack(ctx context.Context, id, trackUUID string, userID int64) error {
+	args := m.Called(ctx, id, trackUUID, userID)
+	return args.Error(0)
+}
+
+func TestRemoveHandler(t *testing.T) {
+	const (
+		id        = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+		trackUUID = "e434dc13-ada5-4bde-b695-d97014dadebc"
+	)
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockTrackRemover)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "track removed",
+			mockSetup: func(m *MockTrackRemover) {
+				m.On("RemoveTrack", mock.Anything, id, trackUUID, int64(1)).Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+		{
+			name:           "anonymous caller",
+			anonymous:      true,
+			mockSetup:      func(m *MockTrackRemover) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "track not in collection",
+			mockSetup: func(m *MockTrackRemover) {
+				m.On("RemoveTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(collectionService.ErrTrackNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track not found"}`,
+		},
+		{
+			name: "collection not found",
+			mockSetup: func(m *MockTrackRemover) {
+				m.On("RemoveTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockTrackRemover) {
+				m.On("RemoveTrack", mock.Anything, id, trackUUID, int64(1)).
+					Return(errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockRemover := new(MockTrackRemover)
+			tt.mockSetup(mockRemover)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.DELETE("/collections/:id/tracks/:uuid", New(context.Background(), log, mockRemover))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/collections/"+id+"/tracks/"+trackUUID, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockRemover.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package reorder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionReorderer interface {
+	Reorder(ctx context.Context, id string, trackUUIDs []string, userID int64) (*model.Collection, error)
+}
+
+// @Summary Reorder a collection
+// @Description Sets the order of the caller's collection tracks.
+// @Description 'tracks' must list every track of the collection exactly once.
+// @Tags collection
+// @Accept json
+// @Produce json
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Param input body dto.ReorderRequest true "Track UUIDs in the new order"
+// @Success 200 {object} dto.CollectionResponse "Reordered collection"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id}/tracks [put]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionReorderer CollectionReorderer,
+) gin.HandlerFunc {
+	const op = "handler.collection.reorder.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		var req dto.ReorderRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Error("request body is empty")
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "request body is empty"})
+				return
+			}
+			log.Error("failed to decode request body", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request"})
+			return
+		}
+
+		collection, err := collectionReorderer.Reorder(ctx, c.Param("id"), req.Tracks, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, collectionService.ErrCollectionNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+			case errors.Is(err, collectionService.ErrInvalidOrder):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: collectionService.ErrInvalidOrder.Error()})
+			default:
+				log.Error("failed to reorder collection", sl.Err(err))
+
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCollectionResponse(collection))
+	}
+}
@@ -0,0 +1,153 @@
+package reorder
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockCollectionReorderer struct {
+	mock.Mock
+}
+
+func (m *MockCollectionReorderer) Reorder(
+	ctx context.Context,
+	id string,
+	trackUUIDs []string,
+	userID int64,
+) (*model.Collection, error) {
+	args := m.Called(ctx, id, trackUUIDs, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func TestReorderHandler(t *testing.T) {
+	const id = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	order := []string{"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11", "e434dc13-ada5-4bde-b695-d97014dadebc"}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		anonymous      bool
+		mockSetup      func(*MockCollectionReorderer)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "collection reordered",
+			requestBody: `{"tracks": ["7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11", "e434dc13-ada5-4bde-b695-d97014dadebc"]}`,
+			mockSetup: func(m *MockCollectionReorderer) {
+				m.On("Reorder", mock.Anything, id, order, int64(1)).
+					Return(&model.Collection{
+						ID:     id,
+						UserID: 1,
+						Name:   "Favorites",
+						Tracks: []*model.Track{
+							{UUID: order[0], Artist: "Juice WRLD", Title: "Robbery", CreatedAt: createdAt, UpdatedAt: createdAt},
+							{UUID: order[1], Artist: "Juice WRLD", Title: "Lucid Dreams", CreatedAt: createdAt, UpdatedAt: createdAt},
+						},
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"id":"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d","name":"Favorites","tracks":[` +
+				`{"uuid":"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11","artist":"Juice WRLD","title":"Robbery","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"},` +
+				`{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}],` +
+				`"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name:           "missing tracks",
+			requestBody:    `{}`,
+			mockSetup:      func(m *MockCollectionReorderer) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request"}`,
+		},
+		{
+			name:           "anonymous caller",
+			requestBody:    `{"tracks": []}`,
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionReorderer) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:        "order misses a track",
+			requestBody: `{"tracks": ["7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11", "e434dc13-ada5-4bde-b695-d97014dadebc"]}`,
+			mockSetup: func(m *MockCollectionReorderer) {
+				m.On("Reorder", mock.Anything, id, order, int64(1)).
+					Return(nil, collectionService.ErrInvalidOrder)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"order must list every track of the collection once"}`,
+		},
+		{
+			name:        "collection not found",
+			requestBody: `{"tracks": ["7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11", "e434dc13-ada5-4bde-b695-d97014dadebc"]}`,
+			mockSetup: func(m *MockCollectionReorderer) {
+				m.On("Reorder", mock.Anything, id, order, int64(1)).
+					Return(nil, collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{"tracks": ["7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11", "e434dc13-ada5-4bde-b695-d97014dadebc"]}`,
+			mockSetup: func(m *MockCollectionReorderer) {
+				m.On("Reorder", mock.Anything, id, order, int64(1)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockReorderer := new(MockCollectionReorderer)
+			tt.mockSetup(mockReorderer)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.PUT("/collections/:id/tracks", New(context.Background(), log, mockReorderer))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/collections/"+id+"/tracks", strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockReorderer.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type CollectionSharer interface {
+	Share(ctx context.Context, id string, userID int64) (string, error)
+}
+
+// @Summary Share a collection
+// @Description Makes the caller's collection readable by anyone at /collections/shared/{slug}.
+// @Description Sharing a shared collection returns its slug.
+// @Tags collection
+// @Produce json
+// @Param id path string true "Collection ID" example(3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d)
+// @Success 200 {object} dto.ShareResponse "Share slug"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 404 {object} dto.ErrorResponse "Collection not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/{id}/share [post]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	collectionSharer CollectionSharer,
+) gin.HandlerFunc {
+	const op = "handler.collection.share.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		slug, err := collectionSharer.Share(ctx, c.Param("id"), userID)
+		if err != nil {
+			if errors.Is(err, collectionService.ErrCollectionNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+				return
+			}
+
+			log.Error("failed to share collection", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ShareResponse{Slug: slug})
+	}
+}
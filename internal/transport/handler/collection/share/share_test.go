@@ -0,0 +1,100 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockCollectionSharer struct {
+	mock.Mock
+}
+
+func (m *MockCollectionSharer) Share(ctx context.Context, id string, userID int64) (string, error) {
+	args := m.Called(ctx, id, userID)
+	return args.String(0), args.Error(1)
+}
+
+func TestShareHandler(t *testing.T) {
+	const id = "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d"
+
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockCollectionSharer)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "collection shared",
+			mockSetup: func(m *MockCollectionSharer) {
+				m.On("Share", mock.Anything, id, int64(1)).Return("q2Vh0Zt1aLw3nXcR", nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"slug":"q2Vh0Zt1aLw3nXcR"}`,
+		},
+		{
+			name:           "anonymous caller",
+			anonymous:      true,
+			mockSetup:      func(m *MockCollectionSharer) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "collection not found",
+			mockSetup: func(m *MockCollectionSharer) {
+				m.On("Share", mock.Anything, id, int64(1)).Return("", collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockCollectionSharer) {
+				m.On("Share", mock.Anything, id, int64(1)).Return("", errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSharer := new(MockCollectionSharer)
+			tt.mockSetup(mockSharer)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.POST("/collections/:id/share", New(context.Background(), log, mockSharer))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/collections/"+id+"/share", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockSharer.AssertExpectations(t)
+		})
+	}
+}
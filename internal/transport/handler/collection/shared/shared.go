@@ -0,0 +1,55 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	collectionService "lyrics-library/internal/service/collection"
+	"lyrics-library/internal/transport/dto"
+)
+
+type SharedCollectionProvider interface {
+	Shared(ctx context.Context, slug string) (*model.Collection, error)
+}
+
+// @Summary Get a shared collection
+// @Description Returns a collection shared by its owner with its tracks in order, no authorization is needed.
+// @Tags collection
+// @Produce json
+// @Param slug path string true "Share slug" example(q2Vh0Zt1aLw3nXcR)
+// @Success 200 {object} dto.CollectionResponse "Shared collection"
+// @Failure 404 {object} dto.ErrorResponse "Collection not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /collections/shared/{slug} [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	sharedCollectionProvider SharedCollectionProvider,
+) gin.HandlerFunc {
+	const op = "handler.collection.shared.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		collection, err := sharedCollectionProvider.Shared(ctx, c.Param("slug"))
+		if err != nil {
+			if errors.Is(err, collectionService.ErrCollectionNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "collection not found"})
+				return
+			}
+
+			log.Error("failed to get shared collection", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToCollectionResponse(collection))
+	}
+}
@@ -0,0 +1,103 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	collectionService "lyrics-library/internal/service/collection"
+)
+
+type MockSharedCollectionProvider struct {
+	mock.Mock
+}
+
+func (m *MockSharedCollectionProvider) Shared(ctx context.Context, slug string) (*model.Collection, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Collection), args.Error(1)
+}
+
+func TestSharedHandler(t *testing.T) {
+	const slug = "q2Vh0Zt1aLw3nXcR"
+
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*MockSharedCollectionProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "shared collection without authorization",
+			mockSetup: func(m *MockSharedCollectionProvider) {
+				m.On("Shared", mock.Anything, slug).
+					Return(&model.Collection{
+						ID:        "3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d",
+						UserID:    1,
+						Name:      "Favorites",
+						Slug:      slug,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"3f1f6b8e-2a5c-4d0e-9b1a-7c2e4f6a8b0d","name":"Favorites","slug":"q2Vh0Zt1aLw3nXcR","tracks":[],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name: "collection not shared",
+			mockSetup: func(m *MockSharedCollectionProvider) {
+				m.On("Shared", mock.Anything, slug).Return(nil, collectionService.ErrCollectionNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"collection not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockSharedCollectionProvider) {
+				m.On("Shared", mock.Anything, slug).Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockSharedCollectionProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/collections/shared/:slug", New(context.Background(), log, mockProvider))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/collections/shared/"+slug, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	jobService "lyrics-library/internal/service/job"
+	"lyrics-library/internal/transport/dto"
+)
+
+type JobProvider interface {
+	Job(ctx context.Context, id string, userID int64) (*model.Job, error)
+}
+
+// @Summary Get a track import job
+// @Description Returns the status of a background track import.
+// @Description A done job has the imported track_uuid, a failed job has the error.
+// @Description Jobs submitted by another user aren't found.
+// @Tags job
+// @Produce json
+// @Param id path string true "Job ID" example(7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11)
+// @Success 200 {object} dto.JobResponse "Job"
+// @Failure 404 {object} dto.ErrorResponse "Job not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /jobs/{id} [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	jobProvider JobProvider,
+) gin.HandlerFunc {
+	const op = "handler.job.get.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		userID, _ := uid.FromContext(c)
+
+		job, err := jobProvider.Job(ctx, c.Param("id"), userID)
+		if err != nil {
+			if errors.Is(err, jobService.ErrJobNotFound) {
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "job not found"})
+				return
+			}
+
+			log.Error("failed to get job", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.ToJobResponse(job))
+	}
+}
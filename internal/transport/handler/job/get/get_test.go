@@ -0,0 +1,130 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+	jobService "lyrics-library/internal/service/job"
+)
+
+type MockJobProvider struct {
+	mock.Mock
+}
+
+func (m *MockJobProvider) Job(ctx context.Context, id string, userID int64) (*model.Job, error) {
+	args := m.Called(ctx, id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Job), args.Error(1)
+}
+
+func TestGetHandler(t *testing.T) {
+	const id = "7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"
+
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		mockSetup      func(*MockJobProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "done job",
+			mockSetup: func(m *MockJobProvider) {
+				m.On("Job", mock.Anything, id, int64(1)).
+					Return(&model.Job{
+						ID:        id,
+						UserID:    1,
+						Artist:    "Juice WRLD",
+						Title:     "Lucid Dreams",
+						Status:    model.JobDone,
+						TrackUUID: "e434dc13-ada5-4bde-b695-d97014dadebc",
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11","status":"done","artist":"Juice WRLD","title":"Lucid Dreams","track_uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name: "failed job",
+			mockSetup: func(m *MockJobProvider) {
+				m.On("Job", mock.Anything, id, int64(1)).
+					Return(&model.Job{
+						ID:        id,
+						UserID:    1,
+						Artist:    "Juice WRLD",
+						Title:     "Lucid Dreams",
+						Lang:      "en",
+						Status:    model.JobFailed,
+						Error:     "track not found",
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"id":"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11","status":"failed","artist":"Juice WRLD","title":"Lucid Dreams","lang":"en","error":"track not found","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name: "job not found",
+			mockSetup: func(m *MockJobProvider) {
+				m.On("Job", mock.Anything, id, int64(1)).
+					Return(nil, jobService.ErrJobNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"job not found"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockJobProvider) {
+				m.On("Job", mock.Anything, id, int64(1)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockJobProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				c.Set(uid.Key, int64(1))
+			})
+			router.GET("/jobs/:id", New(context.Background(), log, mockProvider))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/jobs/"+id, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
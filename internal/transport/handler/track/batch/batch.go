@@ -0,0 +1,130 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/playlist"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type Importer interface {
+	Import(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult
+	Submit(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult
+}
+
+const contentTypeCSV = "text/csv"
+
+// maxItemSize bounds the request body to maxItems items of up to this size,
+// so an oversized playlist isn't read in full before it's rejected
+const maxItemSize = 1 << 10
+
+// writeTimeout is the time the results have to be written in once the import is done,
+// a synchronous import may take longer than the server write timeout
+const writeTimeout = 30 * time.Second
+
+// @Summary Save a list of tracks with translation
+// @Description Imports a JSON array or CSV of artist/title pairs, with an optional lang per track.
+// @Description CSV rows are artist,title[,lang], a header row may set another column order.
+// @Description Every track gets its own result, a failed track doesn't stop the rest.
+// @Description With async=true or more tracks than the sync limit a background import job is queued per track instead.
+// @Tags track
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param input body []dto.CreateRequest true "Tracks to import"
+// @Param lang query string false "Translation language of tracks without their own lang (optional)" example("uk")
+// @Param async query bool false "Import the tracks in background (optional)"
+// @Success 200 {object} dto.BatchResponse "Import results"
+// @Success 202 {object} dto.BatchResponse "Import jobs submitted"
+// @Failure 400 {object} dto.ErrorResponse "Invalid playlist or query parameters"
+// @Failure 413 {object} dto.ErrorResponse "Playlist is too large"
+// @Router /lyrics/batch [post]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	importer Importer,
+	maxItems int,
+	syncMaxItems int,
+) gin.HandlerFunc {
+	const op = "handler.track.batch.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		var query dto.BatchQuery
+		if err := c.ShouldBindQuery(&query); err != nil {
+			log.Error("invalid query parameters", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid query parameters"})
+			return
+		}
+
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, int64(maxItems)*maxItemSize)
+
+		var (
+			items []model.BatchItem
+			err   error
+		)
+
+		if c.ContentType() == contentTypeCSV {
+			items, err = playlist.ParseCSV(body)
+		} else {
+			items, err = playlist.ParseJSON(body)
+		}
+		if err != nil {
+			log.Error("failed to parse playlist", sl.Err(err))
+
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				c.JSON(http.StatusRequestEntityTooLarge,
+					dto.ErrorResponse{Error: fmt.Sprintf("playlist has more than %d tracks", maxItems)})
+				return
+			}
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+
+		switch {
+		case len(items) == 0:
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "playlist is empty"})
+			return
+		case len(items) > maxItems:
+			c.JSON(http.StatusBadRequest,
+				dto.ErrorResponse{Error: fmt.Sprintf("playlist has more than %d tracks", maxItems)})
+			return
+		}
+
+		if query.Lang != "" {
+			for i := range items {
+				if items[i].Lang == "" {
+					items[i].Lang = query.Lang
+				}
+			}
+		}
+
+		userID, _ := uid.FromContext(c)
+
+		if query.Async || len(items) > syncMaxItems {
+			c.JSON(http.StatusAccepted, dto.ToBatchResponse(importer.Submit(ctx, items, userID)))
+			return
+		}
+
+		results := importer.Import(ctx, items, userID)
+
+		rc := http.NewResponseController(c.Writer)
+		_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+		c.JSON(http.StatusOK, dto.ToBatchResponse(results))
+	}
+}
@@ -0,0 +1,167 @@
+package batch
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
+)
+
+type MockImporter struct {
+	mock.Mock
+}
+
+func (m *MockImporter) Import(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult {
+	args := m.Called(ctx, items, userID)
+	return args.Get(0).([]model.BatchResult)
+}
+
+func (m *MockImporter) Submit(ctx context.Context, items []model.BatchItem, userID int64) []model.BatchResult {
+	args := m.Called(ctx, items, userID)
+	return args.Get(0).([]model.BatchResult)
+}
+
+func TestBatchHandler(t *testing.T) {
+	items := []model.BatchItem{
+		{Artist: "Juice WRLD", Title: "Lucid Dreams", Lang: "uk"},
+		{Artist: "Nirvana", Title: "Lithium", Lang: "en"},
+	}
+
+	tests := []struct {
+		name           string
+		contentType    string
+		query          string
+		requestBody    string
+		mockSetup      func(*MockImporter)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "json playlist",
+			contentType: "application/json",
+			query:       "?lang=uk",
+			requestBody: `[{"artist":"Juice WRLD","title":"Lucid Dreams"},{"artist":"Nirvana","title":"Lithium","lang":"en"}]`,
+			mockSetup: func(m *MockImporter) {
+				m.On("Import", mock.Anything, items, int64(1)).
+					Return([]model.BatchResult{
+						{Item: items[0], Status: model.BatchSaved, TrackUUID: "e434dc13-ada5-4bde-b695-d97014dadebc"},
+						{Item: items[1], Status: model.BatchNotFound},
+					})
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"results":[{"artist":"Juice WRLD","title":"Lucid Dreams","lang":"uk","status":"saved","track_uuid":"e434dc13-ada5-4bde-b695-d97014dadebc"},{"artist":"Nirvana","title":"Lithium","lang":"en","status":"not_found"}]}`,
+		},
+		{
+			name:        "async csv playlist",
+			contentType: "text/csv; charset=utf-8",
+			query:       "?async=true&lang=uk",
+			requestBody: "artist,title,lang\nJuice WRLD,Lucid Dreams\nNirvana,Lithium,en\n",
+			mockSetup: func(m *MockImporter) {
+				m.On("Submit", mock.Anything, items, int64(1)).
+					Return([]model.BatchResult{
+						{Item: items[0], Status: model.BatchQueued, JobID: "7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"},
+						{Item: items[1], Status: model.BatchFailed},
+					})
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `{"results":[{"artist":"Juice WRLD","title":"Lucid Dreams","lang":"uk","status":"queued","job_id":"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11"},{"artist":"Nirvana","title":"Lithium","lang":"en","status":"failed"}]}`,
+		},
+		{
+			name:           "invalid item",
+			contentType:    "application/json",
+			requestBody:    `[{"artist":"Nirvana"}]`,
+			mockSetup:      func(m *MockImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid playlist: item 1: title is required"}`,
+		},
+		{
+			name:           "invalid lang",
+			contentType:    "application/json",
+			query:          "?lang=" + strings.Repeat("x", 11),
+			requestBody:    `[{"artist":"Nirvana","title":"Lithium"}]`,
+			mockSetup:      func(m *MockImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid query parameters"}`,
+		},
+		{
+			name:           "empty playlist",
+			contentType:    "application/json",
+			requestBody:    `[]`,
+			mockSetup:      func(m *MockImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"playlist is empty"}`,
+		},
+		{
+			name:        "playlist over the sync limit",
+			contentType: "text/csv",
+			requestBody: "A,1,en\nB,2,en\nC,3,en\n",
+			mockSetup: func(m *MockImporter) {
+				m.On("Submit", mock.Anything, []model.BatchItem{
+					{Artist: "A", Title: "1", Lang: "en"},
+					{Artist: "B", Title: "2", Lang: "en"},
+					{Artist: "C", Title: "3", Lang: "en"},
+				}, int64(1)).
+					Return([]model.BatchResult{})
+			},
+			expectedStatus: http.StatusAccepted,
+			expectedBody:   `{"results":[]}`,
+		},
+		{
+			name:           "too many tracks",
+			contentType:    "text/csv",
+			requestBody:    "A,1\nB,2\nC,3\nD,4\n",
+			mockSetup:      func(m *MockImporter) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"playlist has more than 3 tracks"}`,
+		},
+		{
+			name:           "oversized body",
+			contentType:    "text/csv",
+			requestBody:    "A," + strings.Repeat("x", 4*maxItemSize) + "\n",
+			mockSetup:      func(m *MockImporter) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"playlist has more than 3 tracks"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockImporter := new(MockImporter)
+			tt.mockSetup(mockImporter)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			handler := New(context.Background(), log, mockImporter, 3, 2)
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			req := httptest.NewRequest(http.MethodPost, "/"+tt.query, strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", tt.contentType)
+			ctx.Request = req
+			ctx.Set(uid.Key, int64(1))
+
+			handler(ctx)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockImporter.AssertExpectations(t)
+		})
+	}
+}
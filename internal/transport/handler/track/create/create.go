@@ -9,22 +9,35 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"lyrics-library/internal/domain/model"
 	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
 	trackService "lyrics-library/internal/service/track"
 	"lyrics-library/internal/transport/dto"
 )
 
 type TrackSaver interface {
-	Save(ctx context.Context, artist, title string) (*dto.TrackResponse, error)
+	Save(ctx context.Context, artist, title, lang string, userID int64) (*dto.TrackResponse, bool, error)
+}
+
+type JobSubmitter interface {
+	Submit(ctx context.Context, artist, title, lang string, userID int64) (*model.Job, error)
 }
 
 // @Summary Save a new track with translation
-// @Description Save lyrics and translation for a given artist and song title
+// @Description Save lyrics and translation for a given artist and song title.
+// @Description The track is owned by the authenticated caller.
+// @Description If the track is already stored but not translated into 'lang', the translation is added.
+// @Description With async=true the track is imported in background, the returned job is polled at /jobs/{id}.
 // @Tags track
 // @Accept json
 // @Produce json
 // @Param input body dto.CreateRequest true "Lyrics request data"
+// @Param mode query string false "Response mode, 'lines' pairs every line with its translation (optional)" Enums(lines)
+// @Param async query bool false "Import the track in background (optional)"
+// @Success 200 {object} dto.TrackResponse "Track already exists"
 // @Success 201 {object} dto.TrackResponse "Successfully saved track"
+// @Success 202 {object} dto.JobResponse "Import job submitted"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /track [post]
@@ -32,6 +45,7 @@ func New(
 	ctx context.Context,
 	log *slog.Logger,
 	trackSaver TrackSaver,
+	jobSubmitter JobSubmitter,
 ) gin.HandlerFunc {
 	const op = "handler.track.create.New"
 
@@ -55,7 +69,23 @@ func New(
 
 		log.Debug("request body decoded", slog.Any("request", req))
 
-		track, err := trackSaver.Save(ctx, req.Artist, req.Title)
+		userID, _ := uid.FromContext(c)
+
+		if c.Query("async") == "true" {
+			job, err := jobSubmitter.Submit(ctx, req.Artist, req.Title, req.Lang, userID)
+			if err != nil {
+				log.Error("failed to submit job", sl.Err(err))
+
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+				return
+			}
+
+			c.Header("Location", "/jobs/"+job.ID)
+			c.JSON(http.StatusAccepted, dto.ToJobResponse(job))
+			return
+		}
+
+		track, created, err := trackSaver.Save(ctx, req.Artist, req.Title, req.Lang, userID)
 		if err != nil {
 			log.Error("failed to create track", sl.Err(err))
 
@@ -66,6 +96,9 @@ func New(
 			case errors.Is(err, trackService.ErrFailedTranslateLyrics):
 				c.JSON(http.StatusBadRequest,
 					dto.ErrorResponse{Error: "failed translate lyrics"})
+			case errors.Is(err, trackService.ErrInvalidLang):
+				c.JSON(http.StatusBadRequest,
+					dto.ErrorResponse{Error: "invalid lang"})
 			default:
 				c.JSON(http.StatusInternalServerError,
 					dto.ErrorResponse{Error: "internal server error"})
@@ -73,6 +106,11 @@ func New(
 			return
 		}
 
-		c.JSON(http.StatusCreated, track)
+		if !created {
+			c.JSON(http.StatusOK, dto.TrackView(track, c.Query("mode")))
+			return
+		}
+
+		c.JSON(http.StatusCreated, dto.TrackView(track, c.Query("mode")))
 	}
 }
@@ -9,11 +9,14 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/uid"
 	trackService "lyrics-library/internal/service/track"
 	"lyrics-library/internal/transport/dto"
 )
@@ -22,36 +25,110 @@ type MockTrackSaver struct {
 	mock.Mock
 }
 
-func (m *MockTrackSaver) Save(ctx context.Context, artist, title string) (*dto.TrackResponse, error) {
-	args := m.Called(ctx, artist, title)
+func (m *MockTrackSaver) Save(ctx context.Context, artist, title, lang string, userID int64) (*dto.TrackResponse, bool, error) {
+	args := m.Called(ctx, artist, title, lang, userID)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*dto.TrackResponse), args.Bool(1), args.Error(2)
+}
+
+type MockJobSubmitter struct {
+	mock.Mock
+}
+
+func (m *MockJobSubmitter) Submit(ctx context.Context, artist, title, lang string, userID int64) (*model.Job, error) {
+	args := m.Called(ctx, artist, title, lang, userID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*dto.TrackResponse), args.Error(1)
+	return args.Get(0).(*model.Job), args.Error(1)
 }
 
+var createdAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
 func TestSaveHandler(t *testing.T) {
 	tests := []struct {
-		name           string
-		requestBody    string
-		mockSetup      func(*MockTrackSaver)
-		expectedStatus int
-		expectedBody   string
+		name             string
+		requestBody      string
+		query            string
+		mockSetup        func(*MockTrackSaver)
+		jobMockSetup     func(*MockJobSubmitter)
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
 	}{
 		{
 			name:        "successful create",
 			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
 			mockSetup: func(m *MockTrackSaver) {
-				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams").
+				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
 					Return(&dto.TrackResponse{
+						UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
 						Artist:      "Juice WRLD",
 						Title:       "Lucid Dreams",
+						Lang:        "ru",
 						Lyrics:      []string{"I still see your shadows in my room..."},
 						Translation: []string{"Я все еще вижу твою тени с моей комнате..."},
-					}, nil)
+						CreatedAt:   createdAt,
+						UpdatedAt:   createdAt,
+					}, true, nil)
 			},
 			expectedStatus: http.StatusCreated,
-			expectedBody:   `{"artist":"Juice WRLD","title":"Lucid Dreams","lyrics":["I still see your shadows in my room..."],"translation":["Я все еще вижу твою тени с моей комнате..."]}`,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["I still see your shadows in my room..."],"translation":["Я все еще вижу твою тени с моей комнате..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name:        "track already exists",
+			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
+			mockSetup: func(m *MockTrackSaver) {
+				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
+					Return(&dto.TrackResponse{
+						UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
+						Artist:      "Juice WRLD",
+						Title:       "Lucid Dreams",
+						Lang:        "ru",
+						Lyrics:      []string{"I still see your shadows in my room..."},
+						Translation: []string{"Я все еще вижу твою тени с моей комнате..."},
+						CreatedAt:   createdAt,
+						UpdatedAt:   createdAt,
+					}, false, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["I still see your shadows in my room..."],"translation":["Я все еще вижу твою тени с моей комнате..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+		},
+		{
+			name:        "async import",
+			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams", "lang": "en"}`,
+			query:       "?async=true",
+			mockSetup:   func(m *MockTrackSaver) {},
+			jobMockSetup: func(m *MockJobSubmitter) {
+				m.On("Submit", mock.Anything, "Juice WRLD", "Lucid Dreams", "en", int64(1)).
+					Return(&model.Job{
+						ID:        "7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11",
+						UserID:    1,
+						Artist:    "Juice WRLD",
+						Title:     "Lucid Dreams",
+						Lang:      "en",
+						Status:    model.JobPending,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+					}, nil)
+			},
+			expectedStatus:   http.StatusAccepted,
+			expectedBody:     `{"id":"7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11","status":"pending","artist":"Juice WRLD","title":"Lucid Dreams","lang":"en","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
+			expectedLocation: "/jobs/7d3c0e4e-8f0a-4a51-9f5e-2c1d6b0a9e11",
+		},
+		{
+			name:        "async import submit error",
+			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
+			query:       "?async=true",
+			mockSetup:   func(m *MockTrackSaver) {},
+			jobMockSetup: func(m *MockJobSubmitter) {
+				m.On("Submit", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
+					Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
 		},
 		{
 			name:           "empty request body",
@@ -67,12 +144,19 @@ func TestSaveHandler(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"invalid request"}`,
 		},
+		{
+			name:           "malformed lang",
+			requestBody:    `{"artist": "Juice WRLD", "title": "Lucid Dreams", "lang": "<script>"}`,
+			mockSetup:      func(m *MockTrackSaver) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid request"}`,
+		},
 		{
 			name:        "track not found",
 			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
 			mockSetup: func(m *MockTrackSaver) {
-				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams").
-					Return(nil, trackService.ErrLyricsNotFound)
+				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
+					Return(nil, false, trackService.ErrLyricsNotFound)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"track not found"}`,
@@ -81,8 +165,8 @@ func TestSaveHandler(t *testing.T) {
 			name:        "failed to translate track",
 			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
 			mockSetup: func(m *MockTrackSaver) {
-				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams").
-					Return(nil, trackService.ErrFailedTranslateLyrics)
+				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
+					Return(nil, false, trackService.ErrFailedTranslateLyrics)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"failed translate lyrics"}`,
@@ -91,8 +175,8 @@ func TestSaveHandler(t *testing.T) {
 			name:        "internal server error",
 			requestBody: `{"artist": "Juice WRLD", "title": "Lucid Dreams"}`,
 			mockSetup: func(m *MockTrackSaver) {
-				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams").
-					Return(nil, errors.New("some unexpected error"))
+				m.On("Save", mock.Anything, "Juice WRLD", "Lucid Dreams", "", int64(1)).
+					Return(nil, false, errors.New("some unexpected error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   `{"error":"internal server error"}`,
@@ -108,9 +192,14 @@ func TestSaveHandler(t *testing.T) {
 			mockSaver := new(MockTrackSaver)
 			tt.mockSetup(mockSaver)
 
+			mockSubmitter := new(MockJobSubmitter)
+			if tt.jobMockSetup != nil {
+				tt.jobMockSetup(mockSubmitter)
+			}
+
 			log := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-			handler := New(context.Background(), log, mockSaver)
+			handler := New(context.Background(), log, mockSaver, mockSubmitter)
 
 			gin.SetMode(gin.TestMode)
 			w := httptest.NewRecorder()
@@ -118,18 +207,21 @@ func TestSaveHandler(t *testing.T) {
 
 			var req *http.Request
 			if tt.requestBody != "" {
-				req = httptest.NewRequest("POST", "/", strings.NewReader(tt.requestBody))
+				req = httptest.NewRequest("POST", "/"+tt.query, strings.NewReader(tt.requestBody))
 			} else {
 				req = httptest.NewRequest("POST", "/", nil)
 			}
 			ctx.Request = req
+			ctx.Set(uid.Key, int64(1))
 
 			handler(ctx)
 
 			assert.Equal(t, tt.expectedStatus, w.Code)
 			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			assert.Equal(t, tt.expectedLocation, w.Header().Get("Location"))
 
 			mockSaver.AssertExpectations(t)
+			mockSubmitter.AssertExpectations(t)
 		})
 	}
 }
@@ -8,20 +8,25 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
 	trackService "lyrics-library/internal/service/track"
 	"lyrics-library/internal/transport/dto"
 )
 
 type TrackDeleter interface {
-	Delete(ctx context.Context, uuid string) error
+	Delete(ctx context.Context, uuid string, userID int64, admin bool) error
 }
 
 // @Summary Delete song lyrics
-// @Description Delete song lyrics by uuid
+// @Description Delete song lyrics by uuid. Only the owner of the track or an admin can delete it, tracks without an owner only an admin.
 // @Tags track
 // @Param uuid path string true "Track UUID" example(e434dc13-ada5-4bde-b695-d97014dadebc)
 // @Success 204 "Lyrics deleted successfully"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Track belongs to another user"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /track/{uuid} [delete]
 func New(
@@ -44,14 +49,23 @@ func New(
 			return
 		}
 
-		if err := trackDeleter.Delete(ctx, uuid); err != nil {
-			if errors.Is(err, trackService.ErrInvalidUUID) {
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized delete attempt")
 
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		if err := trackDeleter.Delete(ctx, uuid, userID, role.Has(c, model.RoleAdmin)); err != nil {
+			switch {
+			case errors.Is(err, trackService.ErrInvalidUUID):
 				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "track not found"})
-				return
+			case errors.Is(err, trackService.ErrNotTrackOwner):
+				c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden"})
+			default:
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
 			}
-
-			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
 			return
 		}
 
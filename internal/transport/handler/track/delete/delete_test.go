@@ -13,6 +13,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
 	"lyrics-library/internal/service/track"
 )
 
@@ -20,8 +23,8 @@ type MockTrackDeleter struct {
 	mock.Mock
 }
 
-func (m *MockTrackDeleter) Delete(ctx context.Context, uuid string) error {
-	args := m.Called(ctx, uuid)
+func (m *MockTrackDeleter) Delete(ctx context.Context, uuid string, userID int64, admin bool) error {
+	args := m.Called(ctx, uuid, userID, admin)
 	return args.Error(0)
 }
 
@@ -29,6 +32,8 @@ func TestDeleteHandler(t *testing.T) {
 	tests := []struct {
 		name           string
 		uuidParam      string
+		anonymous      bool
+		admin          bool
 		mockSetup      func(*MockTrackDeleter)
 		expectedStatus int
 		expectedBody   string
@@ -37,7 +42,7 @@ func TestDeleteHandler(t *testing.T) {
 			name:      "successful deletion",
 			uuidParam: "123e4567-e89b-12d3-a456-426614174000",
 			mockSetup: func(m *MockTrackDeleter) {
-				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000").
+				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", int64(1), false).
 					Return(nil)
 			},
 			expectedStatus: http.StatusNoContent,
@@ -54,17 +59,46 @@ func TestDeleteHandler(t *testing.T) {
 			name:      "invalid uuid format",
 			uuidParam: "invalid-uuid",
 			mockSetup: func(m *MockTrackDeleter) {
-				m.On("Delete", mock.Anything, "invalid-uuid").
+				m.On("Delete", mock.Anything, "invalid-uuid", int64(1), false).
 					Return(track.ErrInvalidUUID)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"track not found"}`,
 		},
+		{
+			name:      "track of another user",
+			uuidParam: "123e4567-e89b-12d3-a456-426614174000",
+			mockSetup: func(m *MockTrackDeleter) {
+				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", int64(1), false).
+					Return(track.ErrNotTrackOwner)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"forbidden"}`,
+		},
+		{
+			name:      "admin deletes track of another user",
+			uuidParam: "123e4567-e89b-12d3-a456-426614174000",
+			admin:     true,
+			mockSetup: func(m *MockTrackDeleter) {
+				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", int64(1), true).
+					Return(nil)
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedBody:   "",
+		},
+		{
+			name:           "anonymous caller",
+			uuidParam:      "123e4567-e89b-12d3-a456-426614174000",
+			anonymous:      true,
+			mockSetup:      func(m *MockTrackDeleter) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
 		{
 			name:      "internal server error",
 			uuidParam: "123e4567-e89b-12d3-a456-426614174000",
 			mockSetup: func(m *MockTrackDeleter) {
-				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000").
+				m.On("Delete", mock.Anything, "123e4567-e89b-12d3-a456-426614174000", int64(1), false).
 					Return(errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -97,6 +131,12 @@ func TestDeleteHandler(t *testing.T) {
 			}
 
 			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			if tt.admin {
+				router.Use(func(c *gin.Context) { c.Set(role.Key, []string{model.RoleAdmin}) })
+			}
 			router.DELETE("/lyrics/:uuid", handler)
 
 			w := httptest.NewRecorder()
@@ -0,0 +1,127 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/export"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackExporter interface {
+	Export(ctx context.Context, filter model.ExportFilter, fn func(track *model.Track) error) error
+}
+
+// ownerMe filters the export by the caller's tracks
+const ownerMe = "me"
+
+// writeTimeout is the time a track has to be written in, an export may take
+// longer than the server write timeout as long as the client keeps reading
+const writeTimeout = 30 * time.Second
+
+// @Summary Export tracks
+// @Description Streams stored tracks with translations ordered by artist and title.
+// @Description ndjson holds every translation and suits backups, csv holds one translation per track, cells starting with =, +, -, @, tab or CR are prefixed with ' so spreadsheets don't run them.
+// @Description txt, lrc, srt and ass are zip archives with a file per track,
+// @Description srt and ass are bilingual subtitles showing the translation under every line.
+// @Description Exporting the whole library requires the admin role, others may export their own tracks with owner=me.
+// @Tags track
+// @Produce application/x-ndjson
+// @Produce text/csv
+// @Produce application/zip
+// @Param format query string false "Export format (default ndjson)" Enums(ndjson, csv, txt, lrc, srt, ass)
+// @Param artist query string false "Only tracks of the artist (optional)" example("Juice WRLD")
+// @Param owner query string false "Only tracks saved by the caller (optional)" Enums(me)
+// @Param lang query string false "Translation language of csv, txt, srt and ass (optional)" example("uk")
+// @Success 200 {file} file "Exported tracks"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Whole library export by non-admin"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/export [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackExporter TrackExporter,
+	defaultLang string,
+) gin.HandlerFunc {
+	const op = "handler.track.export.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		format, err := export.Lookup(c.DefaultQuery("format", export.FormatNDJSON))
+		if err != nil {
+			log.Warn("unknown export format", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "format must be one of " + strings.Join(export.Formats(), ", "),
+			})
+			return
+		}
+
+		filter := model.ExportFilter{Artist: c.Query("artist")}
+
+		owner := c.Query("owner")
+		if owner != "" && owner != ownerMe {
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "owner must be me"})
+			return
+		}
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		// the whole library is a bulk export only admins may run
+		if owner == ownerMe {
+			filter.UserID = userID
+		} else if !role.Has(c, model.RoleAdmin) {
+			log.Warn("library export by non-admin", slog.Int64("uid", userID))
+
+			c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden"})
+			return
+		}
+
+		c.Header("Content-Type", format.ContentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", format.Filename))
+
+		rc := http.NewResponseController(c.Writer)
+		w := format.NewWriter(c.Writer, c.DefaultQuery("lang", defaultLang))
+
+		err = trackExporter.Export(ctx, filter, func(track *model.Track) error {
+			_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+
+			return w.Write(track)
+		})
+		if err != nil {
+			log.Error("failed to export tracks", sl.Err(err))
+
+			// once the body is started the status can't change,
+			// the export is left unfinished so the client sees it's broken
+			if !c.Writer.Written() {
+				c.Writer.Header().Del("Content-Disposition")
+				c.Writer.Header().Del("Content-Type")
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			}
+			return
+		}
+
+		if err := w.Close(); err != nil {
+			log.Error("failed to finish export", sl.Err(err))
+		}
+	}
+}
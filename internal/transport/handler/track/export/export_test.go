@@ -0,0 +1,180 @@
+package export
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+)
+
+type MockTrackExporter struct {
+	mock.Mock
+}
+
+func (m *MockTrackExporter) Export(ctx context.Context, filter model.ExportFilter, fn func(track *model.Track) error) error {
+	args := m.Called(ctx, filter, fn)
+
+	tracks, _ := args.Get(0).([]*model.Track)
+	for _, track := range tracks {
+		if err := fn(track); err != nil {
+			return err
+		}
+	}
+
+	return args.Error(1)
+}
+
+func TestExportHandler(t *testing.T) {
+	createdAt := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	track := &model.Track{
+		UUID:         "e434dc13-ada5-4bde-b695-d97014dadebc",
+		Artist:       "Juice WRLD",
+		Title:        "Lucid Dreams",
+		Lyrics:       []model.Stanza{{Lines: []string{"I still see your shadows"}}},
+		Translations: map[string][]model.Stanza{"uk": {{Lines: []string{"Я досі бачу твої тіні"}}}},
+		CreatedAt:    createdAt,
+		UpdatedAt:    createdAt,
+	}
+
+	tests := []struct {
+		name                string
+		query               string
+		authorized          bool
+		admin               bool
+		mockSetup           func(*MockTrackExporter)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:       "ndjson by artist",
+			query:      "?artist=Juice+WRLD",
+			authorized: true,
+			admin:      true,
+			mockSetup: func(m *MockTrackExporter) {
+				m.On("Export", mock.Anything, model.ExportFilter{Artist: "Juice WRLD"}, mock.Anything).
+					Return([]*model.Track{track}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/x-ndjson",
+			expectedBody: `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams",` +
+				`"lyrics":[{"lines":["I still see your shadows"]}],"translations":{"uk":[{"lines":["Я досі бачу твої тіні"]}]},` +
+				`"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}` + "\n",
+		},
+		{
+			name:       "caller's tracks as csv",
+			query:      "?format=csv&owner=me&lang=uk",
+			authorized: true,
+			mockSetup: func(m *MockTrackExporter) {
+				m.On("Export", mock.Anything, model.ExportFilter{UserID: 1}, mock.Anything).
+					Return([]*model.Track{track}, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+			expectedBody: "uuid,artist,title,provider,lang,lyrics,translation,created_at,updated_at\n" +
+				"e434dc13-ada5-4bde-b695-d97014dadebc,Juice WRLD,Lucid Dreams,,uk,I still see your shadows,Я досі бачу твої тіні," +
+				"2025-05-01T12:00:00Z,2025-05-01T12:00:00Z\n",
+		},
+		{
+			name:                "unknown format",
+			query:               "?format=xml",
+			mockSetup:           func(m *MockTrackExporter) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"format must be one of ass, csv, lrc, ndjson, srt, txt"}`,
+		},
+		{
+			name:                "caller's tracks without authorization",
+			query:               "?owner=me",
+			mockSetup:           func(m *MockTrackExporter) {},
+			expectedStatus:      http.StatusUnauthorized,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"unauthorized"}`,
+		},
+		{
+			name:                "library without authorization",
+			mockSetup:           func(m *MockTrackExporter) {},
+			expectedStatus:      http.StatusUnauthorized,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"unauthorized"}`,
+		},
+		{
+			name:                "library by non-admin",
+			authorized:          true,
+			mockSetup:           func(m *MockTrackExporter) {},
+			expectedStatus:      http.StatusForbidden,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"forbidden"}`,
+		},
+		{
+			name:                "unknown owner",
+			query:               "?owner=42",
+			mockSetup:           func(m *MockTrackExporter) {},
+			expectedStatus:      http.StatusBadRequest,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"owner must be me"}`,
+		},
+		{
+			name:       "storage error before any track",
+			authorized: true,
+			admin:      true,
+			mockSetup: func(m *MockTrackExporter) {
+				m.On("Export", mock.Anything, model.ExportFilter{}, mock.Anything).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+			expectedBody:        `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockExporter := new(MockTrackExporter)
+			tt.mockSetup(mockExporter)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if tt.authorized {
+				router.Use(func(c *gin.Context) {
+					c.Set(uid.Key, int64(1))
+				})
+			}
+			if tt.admin {
+				router.Use(func(c *gin.Context) {
+					c.Set(role.Key, []string{model.RoleAdmin})
+				})
+			}
+			router.GET("/lyrics/export", New(context.Background(), log, mockExporter, "ru"))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/lyrics/export"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockExporter.AssertExpectations(t)
+		})
+	}
+}
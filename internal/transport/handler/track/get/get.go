@@ -0,0 +1,73 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackProvider interface {
+	TrackByUUID(ctx context.Context, uuid, lang string) (*dto.TrackResponse, error)
+}
+
+// @Summary Get song lyrics by uuid
+// @Description Returns lyrics and translation of the track with the given uuid
+// @Tags track
+// @Produce json
+// @Param uuid path string true "Track UUID" example(e434dc13-ada5-4bde-b695-d97014dadebc)
+// @Param lang query string false "Translation language, a missing song translation is added (optional)" example("uk")
+// @Param mode query string false "Response mode, 'lines' pairs every line with its translation (optional)" Enums(lines)
+// @Success 200 {object} dto.TrackResponse "Track"
+// @Failure 404 {object} dto.ErrorResponse "Track not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/{uuid} [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackProvider TrackProvider,
+) gin.HandlerFunc {
+	const op = "handler.track.get.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		log.Info("getting track by uuid")
+
+		uuid := c.Param("uuid")
+		if uuid == "" {
+			log.Error("uuid is required")
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "uuid is required"})
+			return
+		}
+
+		track, err := trackProvider.TrackByUUID(ctx, uuid, c.Query("lang"))
+		if err != nil {
+			switch {
+			case errors.Is(err, trackService.ErrTrackNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track not found"})
+				return
+			case errors.Is(err, trackService.ErrFailedTranslateLyrics):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed translate lyrics"})
+				return
+			case errors.Is(err, trackService.ErrInvalidLang):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
+				return
+			}
+
+			log.Error("failed to get track", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.TrackView(track, c.Query("mode")))
+	}
+}
@@ -0,0 +1,130 @@
+package get
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type MockTrackProvider struct {
+	mock.Mock
+}
+
+func (m *MockTrackProvider) TrackByUUID(ctx context.Context, uuid, lang string) (*dto.TrackResponse, error) {
+	args := m.Called(ctx, uuid, lang)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TrackResponse), args.Error(1)
+}
+
+func TestGetHandler(t *testing.T) {
+	const uuid = "e434dc13-ada5-4bde-b695-d97014dadebc"
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockTrackProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful request",
+			mockSetup: func(m *MockTrackProvider) {
+				m.On("TrackByUUID", mock.Anything, uuid, "").
+					Return(&dto.TrackResponse{
+						UUID:        uuid,
+						Artist:      "Juice WRLD",
+						Title:       "Lucid Dreams",
+						Lang:        "ru",
+						Lyrics:      []string{"..."},
+						Translation: []string{"..."},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["..."],"translation":["..."],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:  "lines mode",
+			query: "?mode=lines",
+			mockSetup: func(m *MockTrackProvider) {
+				m.On("TrackByUUID", mock.Anything, uuid, "").
+					Return(&dto.TrackResponse{
+						UUID:        uuid,
+						Artist:      "Juice WRLD",
+						Title:       "Lucid Dreams",
+						Lang:        "ru",
+						Lyrics:      []string{"first", "", "second"},
+						Translation: []string{"первая", ""},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lines":[{"original":"first","translation":"первая"},{"original":"","translation":""},{"original":"second","translation":""}],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "track not found",
+			mockSetup: func(m *MockTrackProvider) {
+				m.On("TrackByUUID", mock.Anything, uuid, "").
+					Return(nil, trackService.ErrTrackNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track not found"}`,
+		},
+		{
+			name:  "invalid lang",
+			query: "?lang=english-please",
+			mockSetup: func(m *MockTrackProvider) {
+				m.On("TrackByUUID", mock.Anything, uuid, "english-please").
+					Return(nil, trackService.ErrInvalidLang)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid lang"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockTrackProvider) {
+				m.On("TrackByUUID", mock.Anything, uuid, "").
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockTrackProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/lyrics/:uuid", New(context.Background(), log, mockProvider))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/lyrics/"+uuid+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type LineProvider interface {
+	LineAt(ctx context.Context, uuid, lang string, at time.Duration) (*dto.TrackLineResponse, error)
+}
+
+// @Summary Get the line at a playback position
+// @Description Returns the line of time-synced lyrics sung at the position with its translation.
+// @Description line is null before the first line, tracks without timing aren't found.
+// @Tags track
+// @Produce json
+// @Param uuid path string true "Track UUID" example(e434dc13-ada5-4bde-b695-d97014dadebc)
+// @Param t query number true "Playback position in seconds" example(83.5)
+// @Param lang query string false "Translation language, a missing song translation is added (optional)" example("uk")
+// @Success 200 {object} dto.TrackLineResponse "Line at the position"
+// @Failure 400 {object} dto.ErrorResponse "Invalid position"
+// @Failure 404 {object} dto.ErrorResponse "Track not found or not time-synced"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/{uuid}/line [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	lineProvider LineProvider,
+) gin.HandlerFunc {
+	const op = "handler.track.line.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		seconds, err := strconv.ParseFloat(c.Query("t"), 64)
+		if err != nil || seconds < 0 || math.IsInf(seconds, 0) || math.IsNaN(seconds) {
+			log.Warn("invalid playback position", slog.String("t", c.Query("t")))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "t must be a position in seconds"})
+			return
+		}
+
+		at := time.Duration(seconds * float64(time.Second))
+
+		line, err := lineProvider.LineAt(ctx, c.Param("uuid"), c.Query("lang"), at)
+		if err != nil {
+			switch {
+			case errors.Is(err, trackService.ErrTrackNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track not found"})
+				return
+			case errors.Is(err, trackService.ErrNotSynced):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track has no synced lyrics"})
+				return
+			case errors.Is(err, trackService.ErrFailedTranslateLyrics):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed translate lyrics"})
+				return
+			case errors.Is(err, trackService.ErrInvalidLang):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
+				return
+			}
+
+			log.Error("failed to get line", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, line)
+	}
+}
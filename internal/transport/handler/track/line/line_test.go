@@ -0,0 +1,144 @@
+package line
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type MockLineProvider struct {
+	mock.Mock
+}
+
+func (m *MockLineProvider) LineAt(ctx context.Context, uuid, lang string, at time.Duration) (*dto.TrackLineResponse, error) {
+	args := m.Called(ctx, uuid, lang, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TrackLineResponse), args.Error(1)
+}
+
+func TestLineHandler(t *testing.T) {
+	const uuid = "e434dc13-ada5-4bde-b695-d97014dadebc"
+
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockLineProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "line at position",
+			query: "?t=83.5&lang=uk",
+			mockSetup: func(m *MockLineProvider) {
+				m.On("LineAt", mock.Anything, uuid, "uk", 83500*time.Millisecond).
+					Return(&dto.TrackLineResponse{
+						UUID:     uuid,
+						Lang:     "uk",
+						Position: 83.5,
+						Line: &dto.TimedLineResponse{
+							Index:       12,
+							Original:    "I still see your shadows in my room",
+							Translation: "Я досі бачу твої тіні у своїй кімнаті",
+							Start:       82.3,
+							End:         86.05,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","lang":"uk","position":83.5,"line":{"index":12,"original":"I still see your shadows in my room","translation":"Я досі бачу твої тіні у своїй кімнаті","start":82.3,"end":86.05}}`,
+		},
+		{
+			name:  "before the first line",
+			query: "?t=0",
+			mockSetup: func(m *MockLineProvider) {
+				m.On("LineAt", mock.Anything, uuid, "", time.Duration(0)).
+					Return(&dto.TrackLineResponse{UUID: uuid, Lang: "ru"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","lang":"ru","position":0,"line":null}`,
+		},
+		{
+			name:           "missing position",
+			mockSetup:      func(m *MockLineProvider) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"t must be a position in seconds"}`,
+		},
+		{
+			name:           "negative position",
+			query:          "?t=-1",
+			mockSetup:      func(m *MockLineProvider) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"t must be a position in seconds"}`,
+		},
+		{
+			name:  "track without timing",
+			query: "?t=1",
+			mockSetup: func(m *MockLineProvider) {
+				m.On("LineAt", mock.Anything, uuid, "", time.Second).
+					Return(nil, trackService.ErrNotSynced)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track has no synced lyrics"}`,
+		},
+		{
+			name:  "track not found",
+			query: "?t=1",
+			mockSetup: func(m *MockLineProvider) {
+				m.On("LineAt", mock.Anything, uuid, "", time.Second).
+					Return(nil, trackService.ErrTrackNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track not found"}`,
+		},
+		{
+			name:  "internal server error",
+			query: "?t=1",
+			mockSetup: func(m *MockLineProvider) {
+				m.On("LineAt", mock.Anything, uuid, "", time.Second).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockLineProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/lyrics/:uuid/line", New(context.Background(), log, mockProvider))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/lyrics/"+uuid+"/line"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package mine
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type UserTracksProvider interface {
+	UserTracks(ctx context.Context, userID int64) ([]*dto.TrackResponse, error)
+}
+
+// @Summary Get caller's tracks
+// @Description Returns all tracks saved by the authenticated caller
+// @Tags track
+// @Produce json
+// @Param mode query string false "Response mode, 'lines' pairs every line with its translation (optional)" Enums(lines)
+// @Success 200 {array} dto.TrackResponse "Caller's tracks"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/mine [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	userTracksProvider UserTracksProvider,
+) gin.HandlerFunc {
+	const op = "handler.track.mine.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		log.Info("getting caller's tracks")
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized request")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		tracks, err := userTracksProvider.UserTracks(ctx, userID)
+		if err != nil {
+			log.Error("failed to get user's tracks", sl.Err(err))
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.TracksView(tracks, c.Query("mode")))
+	}
+}
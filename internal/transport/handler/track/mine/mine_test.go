@@ -0,0 +1,120 @@
+package mine
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+type MockUserTracksProvider struct {
+	mock.Mock
+}
+
+func (m *MockUserTracksProvider) UserTracks(ctx context.Context, userID int64) ([]*dto.TrackResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.TrackResponse), args.Error(1)
+}
+
+var createdAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMineHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		anonymous      bool
+		mockSetup      func(*MockUserTracksProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "successful request",
+			mockSetup: func(m *MockUserTracksProvider) {
+				m.On("UserTracks", mock.Anything, int64(1)).
+					Return([]*dto.TrackResponse{
+						{
+							UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
+							Artist:      "Juice WRLD",
+							Title:       "Lucid Dreams",
+							Lang:        "ru",
+							Lyrics:      []string{"..."},
+							Translation: []string{"..."},
+							CreatedAt:   createdAt,
+							UpdatedAt:   createdAt,
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["..."],"translation":["..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}]`,
+		},
+		{
+			name: "no tracks",
+			mockSetup: func(m *MockUserTracksProvider) {
+				m.On("UserTracks", mock.Anything, int64(1)).
+					Return([]*dto.TrackResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "anonymous caller",
+			anonymous:      true,
+			mockSetup:      func(m *MockUserTracksProvider) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "internal server error",
+			mockSetup: func(m *MockUserTracksProvider) {
+				m.On("UserTracks", mock.Anything, int64(1)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockProvider := new(MockUserTracksProvider)
+			tt.mockSetup(mockProvider)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			handler := New(context.Background(), log, mockProvider)
+
+			gin.SetMode(gin.TestMode)
+			w := httptest.NewRecorder()
+			ctx, _ := gin.CreateTestContext(w)
+
+			ctx.Request = httptest.NewRequest("GET", "/lyrics/mine", nil)
+			if !tt.anonymous {
+				ctx.Set(uid.Key, int64(1))
+			}
+
+			handler(ctx)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockProvider.AssertExpectations(t)
+		})
+	}
+}
@@ -8,26 +8,35 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"lyrics-library/internal/lib/logger/sl"
 	trackService "lyrics-library/internal/service/track"
 	"lyrics-library/internal/transport/dto"
 )
 
 type TrackProvider interface {
-	Track(ctx context.Context, artist, title string) (*dto.TrackResponse, error)
+	Track(ctx context.Context, artist, title, lang string) (*dto.TrackResponse, error)
+	Suggestions(ctx context.Context, artist, title string) ([]*dto.SuggestionResponse, error)
 }
 
 type ArtistTracksProvider interface {
-	ArtistTracks(ctx context.Context, artist string) ([]*dto.TrackResponse, error)
+	ArtistTracks(ctx context.Context, artist, lang string, req dto.PageRequest) (*dto.TracksPageResponse, error)
 }
 
 // @Summary Get song lyrics or artist tracks
 // @Description If 'title' is provided, returns lyrics for the specific song.
-// @Description Otherwise, returns a list of all songs by the artist (without track).
+// @Description Otherwise, returns a page of songs by the artist, next_cursor selects the next page.
 // @Tags track
 // @Param artist query string true "Artist name" example("Juice WRLD")
 // @Param title query string false "Song title (optional)" example("Legends")
-// @Success 200 {object} dto.TrackResponse "Returns lyrics (object) or artist tracks (array)"
+// @Param lang query string false "Translation language, a missing song translation is added (optional)" example("uk")
+// @Param mode query string false "Response mode, 'lines' pairs every line with its translation (optional)" Enums(lines)
+// @Param limit query int false "Page size for artist tracks, 20 by default (optional)" minimum(1) maximum(100)
+// @Param sort query string false "Artist tracks order, '-' prefix sorts descending (optional)" Enums(title, -title, created_at, -created_at)
+// @Param cursor query string false "next_cursor of the previous page (optional)"
+// @Param fields query string false "'summary' lists artist tracks without lyrics and translation (optional)" Enums(summary, full)
+// @Success 200 {object} dto.TrackResponse "Returns lyrics (object) or a page of artist tracks (dto.TracksPageResponse)"
 // @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 404 {object} dto.TrackNotFoundResponse "Track not found, similar tracks are suggested"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error"
 // @Router /track [get]
 func New(
@@ -45,6 +54,7 @@ func New(
 
 		artist := c.Query("artist")
 		title := c.Query("title")
+		lang := c.Query("lang")
 
 		if artist == "" {
 			log.Error("missing 'artist' parameter")
@@ -54,25 +64,62 @@ func New(
 		}
 
 		if title == "" {
-			tracks, err := artistTracksProvider.ArtistTracks(ctx, artist)
+			var req dto.PageRequest
+			if err := c.ShouldBindQuery(&req); err != nil {
+				log.Error("invalid page parameters", sl.Err(err))
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid page parameters"})
+				return
+			}
+
+			page, err := artistTracksProvider.ArtistTracks(ctx, artist, lang, req)
 			if err != nil {
-				if errors.Is(err, trackService.ErrArtistTracksNotFound) {
+				switch {
+				case errors.Is(err, trackService.ErrArtistTracksNotFound):
 					c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "artist tracks not found"})
 					return
+				case errors.Is(err, trackService.ErrInvalidCursor):
+					c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid cursor"})
+					return
+				case errors.Is(err, trackService.ErrInvalidPage):
+					c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid page parameters"})
+					return
+				case errors.Is(err, trackService.ErrInvalidLang):
+					c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
+					return
 				}
 
 				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
 				return
 			}
 
-			c.JSON(http.StatusOK, tracks)
+			c.JSON(http.StatusOK, dto.TracksPageView(page, req.Fields, c.Query("mode")))
 			return
 		}
 
-		track, err := trackProvider.Track(ctx, artist, title)
+		track, err := trackProvider.Track(ctx, artist, title, lang)
 		if err != nil {
 			if errors.Is(err, trackService.ErrTrackNotFound) {
-				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "track not found"})
+				suggestions, err := trackProvider.Suggestions(ctx, artist, title)
+				if err != nil {
+					log.Error("failed to get suggestions", sl.Err(err))
+
+					suggestions = []*dto.SuggestionResponse{}
+				}
+
+				c.JSON(http.StatusNotFound, dto.TrackNotFoundResponse{
+					Error:       "track not found",
+					Suggestions: suggestions,
+				})
+				return
+			}
+
+			switch {
+			case errors.Is(err, trackService.ErrFailedTranslateLyrics):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "failed translate lyrics"})
+				return
+			case errors.Is(err, trackService.ErrInvalidLang):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
 				return
 			}
 
@@ -80,6 +127,6 @@ func New(
 			return
 		}
 
-		c.JSON(http.StatusOK, track)
+		c.JSON(http.StatusOK, dto.TrackView(track, c.Query("mode")))
 	}
 }
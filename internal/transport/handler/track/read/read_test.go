@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,26 +22,40 @@ type MockTrackProvider struct {
 	mock.Mock
 }
 
-func (m *MockTrackProvider) Track(ctx context.Context, artist, title string) (*dto.TrackResponse, error) {
-	args := m.Called(ctx, artist, title)
+func (m *MockTrackProvider) Track(ctx context.Context, artist, title, lang string) (*dto.TrackResponse, error) {
+	args := m.Called(ctx, artist, title, lang)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*dto.TrackResponse), args.Error(1)
 }
 
+func (m *MockTrackProvider) Suggestions(ctx context.Context, artist, title string) ([]*dto.SuggestionResponse, error) {
+	args := m.Called(ctx, artist, title)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.SuggestionResponse), args.Error(1)
+}
+
 type MockArtistTracksProvider struct {
 	mock.Mock
 }
 
-func (m *MockArtistTracksProvider) ArtistTracks(ctx context.Context, artist string) ([]*dto.TrackResponse, error) {
-	args := m.Called(ctx, artist)
+func (m *MockArtistTracksProvider) ArtistTracks(
+	ctx context.Context,
+	artist, lang string,
+	req dto.PageRequest,
+) (*dto.TracksPageResponse, error) {
+	args := m.Called(ctx, artist, lang, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]*dto.TrackResponse), args.Error(1)
+	return args.Get(0).(*dto.TracksPageResponse), args.Error(1)
 }
 
+var createdAt = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
 func TestGetHandler(t *testing.T) {
 	tests := []struct {
 		name               string
@@ -54,69 +69,177 @@ func TestGetHandler(t *testing.T) {
 			name:        "successful track request",
 			queryParams: map[string]string{"artist": "Juice WRLD", "title": "Lucid Dreams"},
 			mockTrackProvider: func(m *MockTrackProvider) {
-				m.On("Track", mock.Anything, "Juice WRLD", "Lucid Dreams").
+				m.On("Track", mock.Anything, "Juice WRLD", "Lucid Dreams", "").
 					Return(&dto.TrackResponse{
+						UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
 						Artist:      "Juice WRLD",
 						Title:       "Lucid Dreams",
+						Lang:        "ru",
 						Lyrics:      []string{"I still see your shadows in my room..."},
 						Translation: []string{"Я все еще вижу твою тени с моей комнате..."},
+						CreatedAt:   createdAt,
+						UpdatedAt:   createdAt,
 					}, nil)
 			},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {},
 			expectedStatus:     http.StatusOK,
-			expectedBody:       `{"artist":"Juice WRLD","title":"Lucid Dreams","lyrics":["I still see your shadows in my room..."],"translation":["Я все еще вижу твою тени с моей комнате..."]}`,
+			expectedBody:       `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["I still see your shadows in my room..."],"translation":["Я все еще вижу твою тени с моей комнате..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}`,
 		},
 		{
 			name:        "track not found",
 			queryParams: map[string]string{"artist": "Unknown", "title": "Nonexistent"},
 			mockTrackProvider: func(m *MockTrackProvider) {
-				m.On("Track", mock.Anything, "Unknown", "Nonexistent").
+				m.On("Track", mock.Anything, "Unknown", "Nonexistent", "").
 					Return(nil, trackService.ErrTrackNotFound)
+				m.On("Suggestions", mock.Anything, "Unknown", "Nonexistent").
+					Return([]*dto.SuggestionResponse{}, nil)
 			},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {},
-			expectedStatus:     http.StatusBadRequest,
-			expectedBody:       `{"error":"track not found"}`,
+			expectedStatus:     http.StatusNotFound,
+			expectedBody:       `{"error":"track not found","suggestions":[]}`,
+		},
+		{
+			name:        "track not found with suggestions",
+			queryParams: map[string]string{"artist": "juice world", "title": "Lucid Dream"},
+			mockTrackProvider: func(m *MockTrackProvider) {
+				m.On("Track", mock.Anything, "juice world", "Lucid Dream", "").
+					Return(nil, trackService.ErrTrackNotFound)
+				m.On("Suggestions", mock.Anything, "juice world", "Lucid Dream").
+					Return([]*dto.SuggestionResponse{
+						{
+							UUID:   "e434dc13-ada5-4bde-b695-d97014dadebc",
+							Artist: "Juice WRLD",
+							Title:  "Lucid Dreams",
+							Score:  0.72,
+						},
+					}, nil)
+			},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {},
+			expectedStatus:     http.StatusNotFound,
+			expectedBody:       `{"error":"track not found","suggestions":[{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","score":0.72}]}`,
+		},
+		{
+			name:        "track not found when suggestions fail",
+			queryParams: map[string]string{"artist": "Unknown", "title": "Nonexistent"},
+			mockTrackProvider: func(m *MockTrackProvider) {
+				m.On("Track", mock.Anything, "Unknown", "Nonexistent", "").
+					Return(nil, trackService.ErrTrackNotFound)
+				m.On("Suggestions", mock.Anything, "Unknown", "Nonexistent").
+					Return(nil, errors.New("database error"))
+			},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {},
+			expectedStatus:     http.StatusNotFound,
+			expectedBody:       `{"error":"track not found","suggestions":[]}`,
 		},
 		{
 			name:              "successful artist tracks request",
 			queryParams:       map[string]string{"artist": "Juice WRLD"},
 			mockTrackProvider: func(m *MockTrackProvider) {},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {
-				m.On("ArtistTracks", mock.Anything, "Juice WRLD").
-					Return([]*dto.TrackResponse{
+				m.On("ArtistTracks", mock.Anything, "Juice WRLD", "", dto.PageRequest{}).
+					Return(&dto.TracksPageResponse{Tracks: []*dto.TrackResponse{
 						{
+							UUID:        "4f1f7c7e-2b0c-4f7a-9d1e-7d3c2c1b5a10",
 							Artist:      "Juice WRLD",
 							Title:       "Lucid Dreams",
+							Lang:        "ru",
 							Lyrics:      []string{"..."},
 							Translation: []string{"..."},
+							CreatedAt:   createdAt,
+							UpdatedAt:   createdAt,
 						},
 						{
+							UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
 							Artist:      "Juice WRLD",
 							Title:       "All Girls Are The Same",
+							Lang:        "ru",
 							Lyrics:      []string{"..."},
 							Translation: []string{"..."},
+							CreatedAt:   createdAt,
+							UpdatedAt:   createdAt,
 						},
+					}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"tracks":[{"uuid":"4f1f7c7e-2b0c-4f7a-9d1e-7d3c2c1b5a10","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["..."],"translation":["..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"},{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"All Girls Are The Same","lang":"ru","lyrics":["..."],"translation":["..."],"created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}]}`,
+		},
+		{
+			name: "artist tracks summary page",
+			queryParams: map[string]string{
+				"artist": "Juice WRLD",
+				"limit":  "1",
+				"sort":   "-created_at",
+				"fields": "summary",
+			},
+			mockTrackProvider: func(m *MockTrackProvider) {},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {
+				m.On("ArtistTracks", mock.Anything, "Juice WRLD", "",
+					dto.PageRequest{Limit: 1, Sort: "-created_at", Fields: "summary"}).
+					Return(&dto.TracksPageResponse{
+						Tracks: []*dto.TrackResponse{
+							{
+								UUID:        "4f1f7c7e-2b0c-4f7a-9d1e-7d3c2c1b5a10",
+								Artist:      "Juice WRLD",
+								Title:       "Lucid Dreams",
+								Lang:        "ru",
+								Lyrics:      []string{"..."},
+								Translation: []string{"..."},
+								CreatedAt:   createdAt,
+								UpdatedAt:   createdAt,
+							},
+						},
+						NextCursor: "next",
 					}, nil)
 			},
 			expectedStatus: http.StatusOK,
-			expectedBody:   `[{"artist":"Juice WRLD","title":"Lucid Dreams","lyrics":["..."],"translation":["..."]},{"artist":"Juice WRLD","title":"All Girls Are The Same","lyrics":["..."],"translation":["..."]}]`,
+			expectedBody:   `{"tracks":[{"uuid":"4f1f7c7e-2b0c-4f7a-9d1e-7d3c2c1b5a10","artist":"Juice WRLD","title":"Lucid Dreams","created_at":"2025-05-01T12:00:00Z","updated_at":"2025-05-01T12:00:00Z"}],"next_cursor":"next"}`,
+		},
+		{
+			name:               "invalid sort",
+			queryParams:        map[string]string{"artist": "Juice WRLD", "sort": "rating"},
+			mockTrackProvider:  func(m *MockTrackProvider) {},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {},
+			expectedStatus:     http.StatusBadRequest,
+			expectedBody:       `{"error":"invalid page parameters"}`,
+		},
+		{
+			name:              "invalid cursor",
+			queryParams:       map[string]string{"artist": "Juice WRLD", "cursor": "broken"},
+			mockTrackProvider: func(m *MockTrackProvider) {},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {
+				m.On("ArtistTracks", mock.Anything, "Juice WRLD", "", dto.PageRequest{Cursor: "broken"}).
+					Return(nil, trackService.ErrInvalidCursor)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid cursor"}`,
 		},
 		{
 			name:              "artist tracks not found",
 			queryParams:       map[string]string{"artist": "Unknown Artist"},
 			mockTrackProvider: func(m *MockTrackProvider) {},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {
-				m.On("ArtistTracks", mock.Anything, "Unknown Artist").
+				m.On("ArtistTracks", mock.Anything, "Unknown Artist", "", dto.PageRequest{}).
 					Return(nil, trackService.ErrArtistTracksNotFound)
 			},
 			expectedStatus: http.StatusBadRequest,
 			expectedBody:   `{"error":"artist tracks not found"}`,
 		},
+		{
+			name:        "failed to translate track",
+			queryParams: map[string]string{"artist": "Juice WRLD", "title": "Lucid Dreams", "lang": "uk"},
+			mockTrackProvider: func(m *MockTrackProvider) {
+				m.On("Track", mock.Anything, "Juice WRLD", "Lucid Dreams", "uk").
+					Return(nil, trackService.ErrFailedTranslateLyrics)
+			},
+			mockTracksProvider: func(m *MockArtistTracksProvider) {},
+			expectedStatus:     http.StatusBadRequest,
+			expectedBody:       `{"error":"failed translate lyrics"}`,
+		},
 		{
 			name:        "internal server error on track request",
 			queryParams: map[string]string{"artist": "Juice WRLD", "title": "Lucid Dreams"},
 			mockTrackProvider: func(m *MockTrackProvider) {
-				m.On("Track", mock.Anything, "Juice WRLD", "Lucid Dreams").
+				m.On("Track", mock.Anything, "Juice WRLD", "Lucid Dreams", "").
 					Return(nil, errors.New("database error"))
 			},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {},
@@ -128,7 +251,7 @@ func TestGetHandler(t *testing.T) {
 			queryParams:       map[string]string{"artist": "Juice WRLD"},
 			mockTrackProvider: func(m *MockTrackProvider) {},
 			mockTracksProvider: func(m *MockArtistTracksProvider) {
-				m.On("ArtistTracks", mock.Anything, "Juice WRLD").
+				m.On("ArtistTracks", mock.Anything, "Juice WRLD", "", dto.PageRequest{}).
 					Return(nil, errors.New("database error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
@@ -0,0 +1,95 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+	maxQueryLen  = 200
+)
+
+type TrackSearcher interface {
+	Search(ctx context.Context, query, lang string, limit int) ([]*dto.SearchResultResponse, error)
+}
+
+// @Summary Search lyrics
+// @Description Full text search by a phrase in the lyrics or in their translation into 'lang'.
+// @Description Results are ranked, found words are wrapped in <mark> tags in the snippet.
+// @Tags track
+// @Produce json
+// @Param q query string true "Phrase to search" example("shadows in my room")
+// @Param lang query string false "Translation language to search in and return (optional)" example("ru")
+// @Param limit query int false "Maximum number of results, 20 by default, up to 100 (optional)"
+// @Success 200 {array} dto.SearchResultResponse "Found tracks, the best match first"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/search [get]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackSearcher TrackSearcher,
+) gin.HandlerFunc {
+	const op = "handler.track.search.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		log.Info("searching tracks")
+
+		query := strings.TrimSpace(c.Query("q"))
+		if query == "" {
+			log.Error("missing 'q' parameter")
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "q is required"})
+			return
+		}
+
+		if len(query) > maxQueryLen {
+			log.Error("query is too long", slog.Int("len", len(query)))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "q is too long"})
+			return
+		}
+
+		limit := defaultLimit
+		if raw := c.Query("limit"); raw != "" {
+			var err error
+
+			limit, err = strconv.Atoi(raw)
+			if err != nil || limit < 1 || limit > maxLimit {
+				log.Error("invalid 'limit' parameter", slog.String("limit", raw))
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid limit"})
+				return
+			}
+		}
+
+		results, err := trackSearcher.Search(ctx, query, c.Query("lang"), limit)
+		if err != nil {
+			log.Error("failed to search tracks", sl.Err(err))
+
+			if errors.Is(err, trackService.ErrInvalidLang) {
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	}
+}
@@ -0,0 +1,135 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/transport/dto"
+)
+
+type MockTrackSearcher struct {
+	mock.Mock
+}
+
+func (m *MockTrackSearcher) Search(
+	ctx context.Context,
+	query, lang string,
+	limit int,
+) ([]*dto.SearchResultResponse, error) {
+	args := m.Called(ctx, query, lang, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dto.SearchResultResponse), args.Error(1)
+}
+
+func TestSearchHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		mockSetup      func(*MockTrackSearcher)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:  "successful search",
+			query: "?q=shadows+in+my+room&lang=ru&limit=5",
+			mockSetup: func(m *MockTrackSearcher) {
+				m.On("Search", mock.Anything, "shadows in my room", "ru", 5).
+					Return([]*dto.SearchResultResponse{
+						{
+							Track: &dto.TrackResponse{
+								UUID:        "e434dc13-ada5-4bde-b695-d97014dadebc",
+								Artist:      "Juice WRLD",
+								Title:       "Lucid Dreams",
+								Lang:        "ru",
+								Lyrics:      []string{"I still see your shadows in my room"},
+								Translation: []string{"Я все еще вижу твои тени в моей комнате"},
+							},
+							Rank:    0.5,
+							Snippet: "I still see your <mark>shadows</mark> in my <mark>room</mark>",
+						},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"track":{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"ru","lyrics":["I still see your shadows in my room"],"translation":["Я все еще вижу твои тени в моей комнате"],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"},"rank":0.5,"snippet":"I still see your <mark>shadows</mark> in my <mark>room</mark>"}]`,
+		},
+		{
+			name:  "nothing found",
+			query: "?q=unknown",
+			mockSetup: func(m *MockTrackSearcher) {
+				m.On("Search", mock.Anything, "unknown", "", defaultLimit).
+					Return([]*dto.SearchResultResponse{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[]`,
+		},
+		{
+			name:           "missing query",
+			query:          "?q=+",
+			mockSetup:      func(m *MockTrackSearcher) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"q is required"}`,
+		},
+		{
+			name:           "query too long",
+			query:          "?q=" + strings.Repeat("a", maxQueryLen+1),
+			mockSetup:      func(m *MockTrackSearcher) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"q is too long"}`,
+		},
+		{
+			name:           "invalid limit",
+			query:          "?q=shadows&limit=1000",
+			mockSetup:      func(m *MockTrackSearcher) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid limit"}`,
+		},
+		{
+			name:  "internal server error",
+			query: "?q=shadows",
+			mockSetup: func(m *MockTrackSearcher) {
+				m.On("Search", mock.Anything, "shadows", "", defaultLimit).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockSearcher := new(MockTrackSearcher)
+			tt.mockSetup(mockSearcher)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/lyrics/search", New(context.Background(), log, mockSearcher))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/lyrics/search"+tt.query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockSearcher.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/uid"
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type TrackUpdater interface {
+	Update(
+		ctx context.Context,
+		uuid string,
+		userID int64,
+		lyrics []string,
+		lang string,
+		translation []string,
+	) (*dto.TrackResponse, error)
+}
+
+// @Summary Replace song lyrics or translation
+// @Description Replace lyrics and/or translation of the track with the given uuid.
+// @Description Omitted fields are left unchanged, 'lang' selects the replaced translation.
+// @Description Lyrics lines in LRC format like '[01:23.45]line' keep their timestamps.
+// @Description The translation must have a line for every lyrics line, new lyrics drop translations into other languages.
+// @Description Only the owner of the track can update it, tracks without an owner can't be updated.
+// @Tags track
+// @Accept json
+// @Produce json
+// @Param uuid path string true "Track UUID" example(e434dc13-ada5-4bde-b695-d97014dadebc)
+// @Param input body dto.UpdateRequest true "New lyrics and/or translation"
+// @Param mode query string false "Response mode, 'lines' pairs every line with its translation (optional)" Enums(lines)
+// @Success 200 {object} dto.TrackResponse "Updated track"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request or translation lines don't match lyrics"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Track belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Track not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error"
+// @Router /lyrics/{uuid} [put]
+func New(
+	ctx context.Context,
+	log *slog.Logger,
+	trackUpdater TrackUpdater,
+) gin.HandlerFunc {
+	const op = "handler.track.update.New"
+
+	return func(c *gin.Context) {
+		log := log.With(slog.String("op", op))
+
+		log.Info("updating track")
+
+		uuid := c.Param("uuid")
+		if uuid == "" {
+			log.Error("uuid is required")
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "uuid is required"})
+			return
+		}
+
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("unauthorized update attempt")
+
+			c.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		var req dto.UpdateRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			if errors.Is(err, io.EOF) {
+				log.Error("request body is empty")
+
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "request body is empty"})
+				return
+			}
+			log.Error("failed to decode request body", sl.Err(err))
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid request"})
+			return
+		}
+
+		if req.Lyrics == nil && req.Translation == nil {
+			log.Error("nothing to update")
+
+			c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "lyrics or translation is required"})
+			return
+		}
+
+		track, err := trackUpdater.Update(ctx, uuid, userID, req.Lyrics, req.Lang, req.Translation)
+		if err != nil {
+			log.Error("failed to update track", sl.Err(err))
+
+			switch {
+			case errors.Is(err, trackService.ErrTrackNotFound):
+				c.JSON(http.StatusNotFound, dto.ErrorResponse{Error: "track not found"})
+			case errors.Is(err, trackService.ErrNotTrackOwner):
+				c.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden"})
+			case errors.Is(err, trackService.ErrInvalidLang):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "invalid lang"})
+			case errors.Is(err, trackService.ErrInvalidTranslation):
+				c.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "translation lines don't match lyrics"})
+			default:
+				c.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+			}
+			return
+		}
+
+		c.JSON(http.StatusOK, dto.TrackView(track, c.Query("mode")))
+	}
+}
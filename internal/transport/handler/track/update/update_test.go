@@ -0,0 +1,163 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/uid"
+	trackService "lyrics-library/internal/service/track"
+	"lyrics-library/internal/transport/dto"
+)
+
+type MockTrackUpdater struct {
+	mock.Mock
+}
+
+func (m *MockTrackUpdater) Update(
+	ctx context.Context,
+	uuid string,
+	userID int64,
+	lyrics []string,
+	lang string,
+	translation []string,
+) (*dto.TrackResponse, error) {
+	args := m.Called(ctx, uuid, userID, lyrics, lang, translation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.TrackResponse), args.Error(1)
+}
+
+func TestUpdateHandler(t *testing.T) {
+	const uuid = "e434dc13-ada5-4bde-b695-d97014dadebc"
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		anonymous      bool
+		mockSetup      func(*MockTrackUpdater)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:        "successful update",
+			requestBody: `{"lang": "uk", "translation": ["Я все еще вижу твои тени"]}`,
+			mockSetup: func(m *MockTrackUpdater) {
+				m.On("Update", mock.Anything, uuid, int64(1),
+					[]string(nil), "uk", []string{"Я все еще вижу твои тени"}).
+					Return(&dto.TrackResponse{
+						UUID:        uuid,
+						Artist:      "Juice WRLD",
+						Title:       "Lucid Dreams",
+						Lang:        "uk",
+						Lyrics:      []string{"I still see your shadows"},
+						Translation: []string{"Я все еще вижу твои тени"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"uuid":"e434dc13-ada5-4bde-b695-d97014dadebc","artist":"Juice WRLD","title":"Lucid Dreams","lang":"uk","lyrics":["I still see your shadows"],"translation":["Я все еще вижу твои тени"],"created_at":"0001-01-01T00:00:00Z","updated_at":"0001-01-01T00:00:00Z"}`,
+		},
+		{
+			name:           "empty request body",
+			requestBody:    "",
+			mockSetup:      func(m *MockTrackUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"request body is empty"}`,
+		},
+		{
+			name:           "nothing to update",
+			requestBody:    `{}`,
+			mockSetup:      func(m *MockTrackUpdater) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"lyrics or translation is required"}`,
+		},
+		{
+			name:           "anonymous caller",
+			requestBody:    `{"lyrics": ["line"]}`,
+			anonymous:      true,
+			mockSetup:      func(m *MockTrackUpdater) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name:        "track not found",
+			requestBody: `{"lyrics": ["line"]}`,
+			mockSetup: func(m *MockTrackUpdater) {
+				m.On("Update", mock.Anything, uuid, int64(1), []string{"line"}, "", []string(nil)).
+					Return(nil, trackService.ErrTrackNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `{"error":"track not found"}`,
+		},
+		{
+			name:        "track of another user",
+			requestBody: `{"lyrics": ["line"]}`,
+			mockSetup: func(m *MockTrackUpdater) {
+				m.On("Update", mock.Anything, uuid, int64(1), []string{"line"}, "", []string(nil)).
+					Return(nil, trackService.ErrNotTrackOwner)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"forbidden"}`,
+		},
+		{
+			name:        "translation not matching lyrics",
+			requestBody: `{"lang": "uk", "translation": ["рядок", "зайвий рядок"]}`,
+			mockSetup: func(m *MockTrackUpdater) {
+				m.On("Update", mock.Anything, uuid, int64(1),
+					[]string(nil), "uk", []string{"рядок", "зайвий рядок"}).
+					Return(nil, trackService.ErrInvalidTranslation)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"translation lines don't match lyrics"}`,
+		},
+		{
+			name:        "internal server error",
+			requestBody: `{"lyrics": ["line"]}`,
+			mockSetup: func(m *MockTrackUpdater) {
+				m.On("Update", mock.Anything, uuid, int64(1), []string{"line"}, "", []string(nil)).
+					Return(nil, errors.New("database error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUpdater := new(MockTrackUpdater)
+			tt.mockSetup(mockUpdater)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			if !tt.anonymous {
+				router.Use(func(c *gin.Context) { c.Set(uid.Key, int64(1)) })
+			}
+			router.PUT("/lyrics/:uuid", New(context.Background(), log, mockUpdater))
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/lyrics/"+uuid, strings.NewReader(tt.requestBody))
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.JSONEq(t, tt.expectedBody, w.Body.String())
+
+			mockUpdater.AssertExpectations(t)
+		})
+	}
+}
@@ -1,18 +1,46 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
-	"lyrics-library/internal/client/grpc/auth"
+	"lyrics-library/internal/lib/logger/sl"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/service/apikey"
+	tokenService "lyrics-library/internal/service/token"
 	"lyrics-library/internal/transport/dto"
 )
 
+type TokenParser interface {
+	ParseToken(ctx context.Context, token string) (int64, error)
+}
+
+type KeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string) (int64, error)
+}
+
+type RoleProvider interface {
+	Roles(ctx context.Context, userID int64, token string) ([]string, error)
+}
+
+const bearerScheme = "Bearer "
+
+// New identifies the caller by the Authorization header holding a token
+// or an API key as a bearer credential, or by the token cookie.
+// Requests without credentials pass as anonymous and are rejected by routes
+// requiring authorization, requests with invalid ones are rejected right away.
+// Roles that failed to load are left unset, so routes requiring a role deny the caller
 func New(
 	log *slog.Logger,
-	authClient *auth.Client,
+	tokenParser TokenParser,
+	keyAuthenticator KeyAuthenticator,
+	roleProvider RoleProvider,
 ) gin.HandlerFunc {
 	log = log.With(
 		slog.String("component", "middleware/auth"),
@@ -21,26 +49,94 @@ func New(
 	log.Info("auth middleware enabled")
 
 	return func(c *gin.Context) {
-		token, err := c.Cookie("jwt")
-		if err != nil {
-			log.Warn("token cookie not found")
+		credential, ok := credential(c)
+		if !ok {
+			log.Warn("malformed authorization header")
 
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid authorization header"})
 			return
 		}
 
-		log.Info("token cookie found")
+		if credential == "" {
+			log.Debug("credentials not found")
 
-		uid, err := authClient.ParseToken(c.Request.Context(), token)
-		if err != nil {
-			log.Error("provided invalid token")
-
-			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid token"})
+			c.Next()
 			return
 		}
 
-		log.Info("token is valid")
+		var (
+			userID int64
+			token  string
+			err    error
+		)
+
+		if apikey.IsKey(credential) {
+			userID, err = keyAuthenticator.Authenticate(c.Request.Context(), credential)
+			if err != nil {
+				if errors.Is(err, apikey.ErrInvalidKey) {
+					log.Warn("provided invalid api key", sl.Err(err))
+
+					c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid api key"})
+					return
+				}
+
+				log.Error("failed to check api key", sl.Err(err))
+
+				c.AbortWithStatusJSON(http.StatusInternalServerError, dto.ErrorResponse{Error: "internal server error"})
+				return
+			}
+		} else {
+			token = credential
+
+			userID, err = tokenParser.ParseToken(c.Request.Context(), token)
+			if err != nil {
+				if errors.Is(err, tokenService.ErrInvalidToken) {
+					log.Warn("provided invalid token", sl.Err(err))
+
+					c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "invalid token"})
+					return
+				}
+
+				// the caller can't be identified, so the request is rejected
+				// rather than let through as anonymous
+				log.Error("failed to validate token", sl.Err(err))
+
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, dto.ErrorResponse{Error: "auth service unavailable"})
+				return
+			}
+		}
+
+		c.Set(uid.Key, userID)
+
+		roles, err := roleProvider.Roles(c.Request.Context(), userID, token)
+		if err != nil {
+			log.Error("failed to get caller's roles", sl.Err(err))
+		} else {
+			c.Set(role.Key, roles)
+		}
 
-		c.Set("uid", uid)
 		c.Next()
 	}
 }
+
+// credential returns the bearer credential of the Authorization header,
+// the token cookie if the header isn't set or an empty string if neither is.
+// false is returned if the header is set but isn't a bearer credential
+func credential(c *gin.Context) (string, bool) {
+	if header := c.GetHeader("Authorization"); header != "" {
+		if len(header) < len(bearerScheme) || !strings.EqualFold(header[:len(bearerScheme)], bearerScheme) {
+			return "", false
+		}
+
+		credential := strings.TrimSpace(header[len(bearerScheme):])
+
+		return credential, credential != ""
+	}
+
+	token, err := c.Cookie("jwt")
+	if err != nil {
+		return "", true
+	}
+
+	return token, true
+}
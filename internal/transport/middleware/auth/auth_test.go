@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/service/apikey"
+	tokenService "lyrics-library/internal/service/token"
+)
+
+type MockTokenParser struct {
+	mock.Mock
+}
+
+func (m *MockTokenParser) ParseToken(ctx context.Context, token string) (int64, error) {
+	args := m.Called(ctx, token)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockKeyAuthenticator struct {
+	mock.Mock
+}
+
+func (m *MockKeyAuthenticator) Authenticate(ctx context.Context, key string) (int64, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockRoleProvider struct {
+	mock.Mock
+}
+
+func (m *MockRoleProvider) Roles(ctx context.Context, userID int64, token string) ([]string, error) {
+	args := m.Called(ctx, userID, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		cookie         string
+		header         string
+		mockSetup      func(*MockTokenParser, *MockKeyAuthenticator, *MockRoleProvider)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:   "valid token",
+			cookie: "valid-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "valid-token").Return(int64(1), nil)
+				r.On("Roles", mock.Anything, int64(1), "valid-token").Return([]string{"admin"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=1 roles=[admin] known=true",
+		},
+		{
+			name:   "bearer token",
+			header: "Bearer valid-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "valid-token").Return(int64(1), nil)
+				r.On("Roles", mock.Anything, int64(1), "valid-token").Return([]string{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=1 roles=[] known=true",
+		},
+		{
+			name:   "header takes precedence over cookie",
+			cookie: "cookie-token",
+			header: "bearer header-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "header-token").Return(int64(2), nil)
+				r.On("Roles", mock.Anything, int64(2), "header-token").Return([]string{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=2 roles=[] known=true",
+		},
+		{
+			name:   "api key",
+			header: "Bearer lk_secret",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				k.On("Authenticate", mock.Anything, "lk_secret").Return(int64(3), nil)
+				r.On("Roles", mock.Anything, int64(3), "").Return([]string{"admin"}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=3 roles=[admin] known=true",
+		},
+		{
+			name:   "invalid api key",
+			header: "Bearer lk_revoked",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				k.On("Authenticate", mock.Anything, "lk_revoked").Return(int64(0), apikey.ErrInvalidKey)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"invalid api key"}`,
+		},
+		{
+			name:   "auth service unavailable",
+			header: "Bearer valid-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "valid-token").Return(int64(0), errors.New("circuit breaker is open"))
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   `{"error":"auth service unavailable"}`,
+		},
+		{
+			name:   "api key lookup failed",
+			header: "Bearer lk_secret",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				k.On("Authenticate", mock.Anything, "lk_secret").Return(int64(0), errors.New("db error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   `{"error":"internal server error"}`,
+		},
+		{
+			name:           "not a bearer credential",
+			header:         "Basic dXNlcjpwYXNz",
+			mockSetup:      func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"invalid authorization header"}`,
+		},
+		{
+			name:           "empty bearer credential",
+			header:         "Bearer  ",
+			mockSetup:      func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"invalid authorization header"}`,
+		},
+		{
+			name:           "no credentials",
+			mockSetup:      func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=0 roles=[] known=false",
+		},
+		{
+			name:   "invalid token",
+			cookie: "invalid-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "invalid-token").Return(int64(0), tokenService.ErrInvalidToken)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"invalid token"}`,
+		},
+		{
+			name:   "roles failed to load",
+			cookie: "valid-token",
+			mockSetup: func(p *MockTokenParser, k *MockKeyAuthenticator, r *MockRoleProvider) {
+				p.On("ParseToken", mock.Anything, "valid-token").Return(int64(1), nil)
+				r.On("Roles", mock.Anything, int64(1), "valid-token").Return(nil, errors.New("db error"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "uid=1 roles=[] known=false",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockParser := new(MockTokenParser)
+			mockKeys := new(MockKeyAuthenticator)
+			mockRoles := new(MockRoleProvider)
+			tt.mockSetup(mockParser, mockKeys, mockRoles)
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(New(log, mockParser, mockKeys, mockRoles))
+			router.GET("/", func(c *gin.Context) {
+				userID, _ := uid.FromContext(c)
+				roles, known := role.FromContext(c)
+
+				c.String(http.StatusOK, fmt.Sprintf("uid=%d roles=%v known=%t", userID, roles, known))
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "jwt", Value: tt.cookie})
+			}
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+
+			mockParser.AssertExpectations(t)
+			mockKeys.AssertExpectations(t)
+			mockRoles.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package policy
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+	"lyrics-library/internal/transport/dto"
+)
+
+// Authenticated rejects anonymous callers with 401
+func Authenticated(log *slog.Logger) gin.HandlerFunc {
+	log = log.With(slog.String("component", "middleware/policy"))
+
+	return func(c *gin.Context) {
+		if _, ok := uid.FromContext(c); !ok {
+			log.Warn("anonymous request to protected route", slog.String("path", c.FullPath()))
+
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// Role lets through callers having any of the roles. Anonymous callers
+// are rejected with 401, others with 403 including callers whose roles
+// failed to load
+func Role(log *slog.Logger, roles ...string) gin.HandlerFunc {
+	log = log.With(slog.String("component", "middleware/policy"))
+
+	return func(c *gin.Context) {
+		userID, ok := uid.FromContext(c)
+		if !ok {
+			log.Warn("anonymous request to protected route", slog.String("path", c.FullPath()))
+
+			c.AbortWithStatusJSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "unauthorized"})
+			return
+		}
+
+		callerRoles, _ := role.FromContext(c)
+
+		if !slices.ContainsFunc(roles, func(r string) bool { return slices.Contains(callerRoles, r) }) {
+			log.Warn("caller lacks required role",
+				slog.Int64("uid", userID),
+				slog.String("path", c.FullPath()),
+				slog.Any("roles", roles),
+			)
+
+			c.AbortWithStatusJSON(http.StatusForbidden, dto.ErrorResponse{Error: "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
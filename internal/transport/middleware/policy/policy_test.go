@@ -0,0 +1,109 @@
+package policy
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"lyrics-library/internal/domain/model"
+	"lyrics-library/internal/lib/role"
+	"lyrics-library/internal/lib/uid"
+)
+
+func TestPolicies(t *testing.T) {
+	tests := []struct {
+		name           string
+		policy         func(log *slog.Logger) gin.HandlerFunc
+		userID         int64
+		roles          []string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "authenticated caller",
+			policy:         Authenticated,
+			userID:         1,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name:           "anonymous caller of authenticated route",
+			policy:         Authenticated,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+		{
+			name: "admin",
+			policy: func(log *slog.Logger) gin.HandlerFunc {
+				return Role(log, model.RoleAdmin)
+			},
+			userID:         1,
+			roles:          []string{"editor", model.RoleAdmin},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "ok",
+		},
+		{
+			name: "caller without role",
+			policy: func(log *slog.Logger) gin.HandlerFunc {
+				return Role(log, model.RoleAdmin)
+			},
+			userID:         1,
+			roles:          []string{},
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"forbidden"}`,
+		},
+		{
+			name: "roles failed to load",
+			policy: func(log *slog.Logger) gin.HandlerFunc {
+				return Role(log, model.RoleAdmin)
+			},
+			userID:         1,
+			expectedStatus: http.StatusForbidden,
+			expectedBody:   `{"error":"forbidden"}`,
+		},
+		{
+			name: "anonymous caller of admin route",
+			policy: func(log *slog.Logger) gin.HandlerFunc {
+				return Role(log, model.RoleAdmin)
+			},
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   `{"error":"unauthorized"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(func(c *gin.Context) {
+				if tt.userID != 0 {
+					c.Set(uid.Key, tt.userID)
+				}
+				if tt.roles != nil {
+					c.Set(role.Key, tt.roles)
+				}
+			})
+			router.GET("/protected", tt.policy(log), func(c *gin.Context) {
+				c.String(http.StatusOK, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedBody, w.Body.String())
+		})
+	}
+}
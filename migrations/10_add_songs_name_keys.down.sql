@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_songs_title_key_trgm;
+DROP INDEX IF EXISTS idx_songs_artist_key_trgm;
+DROP INDEX IF EXISTS idx_songs_name_keys;
+
+ALTER TABLE songs
+DROP COLUMN title_key,
+DROP COLUMN artist_key;
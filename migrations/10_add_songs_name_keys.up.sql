@@ -0,0 +1,30 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- mirrors normalize.Name: no diacritics, lower case, no "feat." suffix and punctuation
+CREATE FUNCTION pg_temp.normalize_name(name TEXT) RETURNS TEXT AS $$
+    SELECT trim(regexp_replace(
+        regexp_replace(
+            regexp_replace(lower(unaccent(name)), '[\s(\[]+(featuring|feat|ft)\y.*$', ''),
+            '[^[:alnum:][:space:]]', '', 'g'
+        ),
+        '\s+', ' ', 'g'
+    ))
+$$ LANGUAGE sql;
+
+ALTER TABLE songs
+ADD COLUMN artist_key TEXT,
+ADD COLUMN title_key TEXT;
+
+UPDATE songs
+SET artist_key = pg_temp.normalize_name(artist),
+    title_key = pg_temp.normalize_name(title);
+
+ALTER TABLE songs
+ALTER COLUMN artist_key SET NOT NULL,
+ALTER COLUMN title_key SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_songs_name_keys ON songs (artist_key, title_key);
+
+CREATE INDEX IF NOT EXISTS idx_songs_artist_key_trgm ON songs USING GIN (artist_key gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_songs_title_key_trgm ON songs USING GIN (title_key gin_trgm_ops);
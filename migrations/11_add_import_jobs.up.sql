@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS import_jobs
+(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id BIGINT,
+    artist VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    lang VARCHAR(10) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT NOT NULL DEFAULT '',
+    track_uuid UUID REFERENCES songs (uuid) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- workers claim the oldest pending job
+CREATE INDEX IF NOT EXISTS idx_import_jobs_pending ON import_jobs (created_at)
+WHERE status = 'pending';
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS collections
+(
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    -- slug is set while the collection is shared read-only
+    slug VARCHAR(32) UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS collection_tracks
+(
+    collection_id UUID NOT NULL REFERENCES collections (id) ON DELETE CASCADE,
+    song_uuid UUID NOT NULL REFERENCES songs (uuid) ON DELETE CASCADE,
+    position INT NOT NULL,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, song_uuid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_tracks_position
+ON collection_tracks (collection_id, position);
@@ -0,0 +1,9 @@
+-- roles of users registered in the auth service, used when AUTH_ROLES_SOURCE=table.
+-- an admin is added by hand: INSERT INTO user_roles (user_id, role) VALUES (1, 'admin');
+CREATE TABLE IF NOT EXISTS user_roles
+(
+    user_id BIGINT NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, role)
+);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_songs_name_keys;
+
+CREATE INDEX IF NOT EXISTS idx_songs_name_keys ON songs (artist_key, title_key);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_natural_key
+ON songs (lower(artist), lower(title));
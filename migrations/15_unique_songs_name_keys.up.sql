@@ -0,0 +1,44 @@
+-- songs sharing normalized names are merged into the oldest row
+CREATE TEMP TABLE song_keepers AS
+SELECT uuid,
+       first_value(uuid) OVER (
+           PARTITION BY artist_key, title_key
+           ORDER BY created_at, uuid
+       ) AS keeper
+FROM songs;
+
+DELETE FROM song_keepers WHERE uuid = keeper;
+
+UPDATE translations t
+SET song_uuid = k.keeper
+FROM song_keepers k
+WHERE t.song_uuid = k.uuid
+  AND NOT EXISTS (
+      SELECT 1 FROM translations kt
+      WHERE kt.song_uuid = k.keeper AND kt.lang = t.lang
+  );
+
+UPDATE collection_tracks c
+SET song_uuid = k.keeper
+FROM song_keepers k
+WHERE c.song_uuid = k.uuid
+  AND NOT EXISTS (
+      SELECT 1 FROM collection_tracks kc
+      WHERE kc.collection_id = c.collection_id AND kc.song_uuid = k.keeper
+  );
+
+UPDATE import_jobs j
+SET track_uuid = k.keeper
+FROM song_keepers k
+WHERE j.track_uuid = k.uuid;
+
+DELETE FROM songs s
+USING song_keepers k
+WHERE s.uuid = k.uuid;
+
+DROP TABLE song_keepers;
+
+DROP INDEX IF EXISTS idx_songs_natural_key;
+DROP INDEX IF EXISTS idx_songs_name_keys;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_name_keys ON songs (artist_key, title_key);
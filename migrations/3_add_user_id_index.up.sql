@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_songs_user_id ON songs (user_id);
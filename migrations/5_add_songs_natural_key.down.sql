@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_songs_natural_key;
+
+ALTER TABLE songs
+DROP COLUMN target_lang;
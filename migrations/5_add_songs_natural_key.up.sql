@@ -0,0 +1,12 @@
+ALTER TABLE songs
+ADD COLUMN target_lang VARCHAR(10) NOT NULL DEFAULT 'ru';
+
+DELETE FROM songs a
+USING songs b
+WHERE lower(a.artist) = lower(b.artist)
+  AND lower(a.title) = lower(b.title)
+  AND a.target_lang = b.target_lang
+  AND (a.created_at, a.ctid) > (b.created_at, b.ctid);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_natural_key
+ON songs (lower(artist), lower(title), target_lang);
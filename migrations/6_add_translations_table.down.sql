@@ -0,0 +1,26 @@
+ALTER TABLE songs
+ADD COLUMN target_lang VARCHAR(10) NOT NULL DEFAULT 'ru',
+ADD COLUMN translation TEXT[] NOT NULL DEFAULT '{}';
+
+-- only one translation per song survives, russian is preferred
+UPDATE songs s
+SET target_lang = t.lang, translation = t.lines
+FROM (
+    SELECT DISTINCT ON (song_uuid) song_uuid, lang, lines
+    FROM translations
+    ORDER BY song_uuid, lang <> 'ru', created_at
+) t
+WHERE t.song_uuid = s.uuid;
+
+ALTER TABLE songs
+ALTER COLUMN translation DROP DEFAULT;
+
+DROP TABLE IF EXISTS translations;
+
+DROP INDEX IF EXISTS idx_songs_natural_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_natural_key
+ON songs (lower(artist), lower(title), target_lang);
+
+ALTER TABLE songs
+DROP CONSTRAINT songs_pkey;
@@ -0,0 +1,40 @@
+ALTER TABLE songs
+ADD PRIMARY KEY (uuid);
+
+CREATE TABLE IF NOT EXISTS translations
+(
+    song_uuid UUID NOT NULL REFERENCES songs (uuid) ON DELETE CASCADE,
+    lang VARCHAR(10) NOT NULL,
+    lines TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (song_uuid, lang)
+);
+
+INSERT INTO translations (song_uuid, lang, lines, created_at)
+SELECT uuid, target_lang, translation, created_at FROM songs;
+
+-- songs stored once per language are merged into the oldest row
+WITH ranked AS (
+    SELECT uuid,
+           first_value(uuid) OVER (
+               PARTITION BY lower(artist), lower(title)
+               ORDER BY created_at, ctid
+           ) AS keeper
+    FROM songs
+)
+UPDATE translations t
+SET song_uuid = r.keeper
+FROM ranked r
+WHERE t.song_uuid = r.uuid AND r.uuid <> r.keeper;
+
+DELETE FROM songs s
+WHERE NOT EXISTS (SELECT 1 FROM translations t WHERE t.song_uuid = s.uuid);
+
+DROP INDEX IF EXISTS idx_songs_natural_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_songs_natural_key
+ON songs (lower(artist), lower(title));
+
+ALTER TABLE songs
+DROP COLUMN translation,
+DROP COLUMN target_lang;
@@ -0,0 +1,5 @@
+ALTER TABLE songs
+ADD COLUMN provider VARCHAR(50) NOT NULL DEFAULT 'lyricsovh';
+
+ALTER TABLE songs
+ALTER COLUMN provider DROP DEFAULT;
@@ -0,0 +1,29 @@
+-- mirrors lyrics.Flatten: stanzas are separated by an empty line, labels become [Label] markers
+CREATE FUNCTION pg_temp.stanzas_to_lines(stanzas JSONB) RETURNS TEXT[] AS $$
+DECLARE
+    result TEXT[] := '{}';
+    stanza JSONB;
+    first  BOOLEAN := TRUE;
+BEGIN
+    FOR stanza IN SELECT value FROM jsonb_array_elements(COALESCE(stanzas, '[]')) LOOP
+        IF NOT first THEN
+            result := result || ''::TEXT;
+        END IF;
+        first := FALSE;
+
+        IF COALESCE(stanza ->> 'label', '') <> '' THEN
+            result := result || format('[%s]', stanza ->> 'label');
+        END IF;
+
+        result := result || ARRAY(SELECT jsonb_array_elements_text(stanza -> 'lines'));
+    END LOOP;
+
+    RETURN result;
+END
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE songs
+ALTER COLUMN lyrics TYPE TEXT[] USING pg_temp.stanzas_to_lines(lyrics);
+
+ALTER TABLE translations
+ALTER COLUMN lines TYPE TEXT[] USING pg_temp.stanzas_to_lines(lines);
@@ -0,0 +1,45 @@
+-- mirrors lyrics.Parse: an empty line or a [Section] marker starts a new stanza
+CREATE FUNCTION pg_temp.lines_to_stanzas(lines TEXT[]) RETURNS JSONB AS $$
+DECLARE
+    result JSONB := '[]';
+    stanza JSONB;
+    line   TEXT;
+    label  TEXT;
+BEGIN
+    FOREACH line IN ARRAY COALESCE(lines, '{}') LOOP
+        IF line = '' THEN
+            IF stanza IS NOT NULL THEN
+                result := result || jsonb_build_array(stanza);
+                stanza := NULL;
+            END IF;
+            CONTINUE;
+        END IF;
+
+        label := substring(line FROM '^\[([^\]0-9][^\]]*)\]$');
+        IF label IS NOT NULL THEN
+            IF stanza IS NOT NULL THEN
+                result := result || jsonb_build_array(stanza);
+            END IF;
+            stanza := jsonb_build_object('label', label, 'lines', '[]'::JSONB);
+            CONTINUE;
+        END IF;
+
+        IF stanza IS NULL THEN
+            stanza := jsonb_build_object('lines', '[]'::JSONB);
+        END IF;
+        stanza := jsonb_set(stanza, '{lines}', (stanza -> 'lines') || to_jsonb(line));
+    END LOOP;
+
+    IF stanza IS NOT NULL THEN
+        result := result || jsonb_build_array(stanza);
+    END IF;
+
+    RETURN result;
+END
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+ALTER TABLE songs
+ALTER COLUMN lyrics TYPE JSONB USING pg_temp.lines_to_stanzas(lyrics);
+
+ALTER TABLE translations
+ALTER COLUMN lines TYPE JSONB USING pg_temp.lines_to_stanzas(lines);
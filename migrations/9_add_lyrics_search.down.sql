@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_translations_search_vector;
+DROP INDEX IF EXISTS idx_songs_search_vector;
+
+ALTER TABLE translations
+DROP COLUMN search_vector;
+
+ALTER TABLE songs
+DROP COLUMN search_vector;
+
+DROP FUNCTION IF EXISTS ts_config(TEXT);
+DROP FUNCTION IF EXISTS lyrics_text(JSONB);
@@ -0,0 +1,41 @@
+-- lyrics_text joins the lines of stanzas for indexing and highlighting
+CREATE FUNCTION lyrics_text(stanzas JSONB) RETURNS TEXT AS $$
+    SELECT COALESCE(string_agg(l.line, E'\n' ORDER BY s.ord, l.ord), '')
+    FROM jsonb_array_elements(stanzas) WITH ORDINALITY AS s(stanza, ord),
+         jsonb_array_elements_text(s.stanza -> 'lines') WITH ORDINALITY AS l(line, ord)
+$$ LANGUAGE sql IMMUTABLE;
+
+-- ts_config maps a language code to a text search configuration
+CREATE FUNCTION ts_config(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lower(lang)
+        WHEN 'ar' THEN 'arabic'
+        WHEN 'da' THEN 'danish'
+        WHEN 'de' THEN 'german'
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'hu' THEN 'hungarian'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'no' THEN 'norwegian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'ro' THEN 'romanian'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END::regconfig
+$$ LANGUAGE sql IMMUTABLE;
+
+-- the source language of lyrics isn't known, so they are indexed without stemming
+ALTER TABLE songs
+ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector('simple', lyrics_text(lyrics))) STORED;
+
+ALTER TABLE translations
+ADD COLUMN search_vector TSVECTOR
+    GENERATED ALWAYS AS (to_tsvector(ts_config(lang), lyrics_text(lines))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_songs_search_vector ON songs USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_translations_search_vector ON translations USING GIN (search_vector);